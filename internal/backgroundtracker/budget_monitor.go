@@ -0,0 +1,94 @@
+package backgroundtracker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// budgetThresholds are the used parts of a project budget at which a notification is emitted
+var budgetThresholds = []float64{0.8, 1.0}
+
+// monitorBudget periodically checks the budget of the active entry's project and notifies once the used part of the
+// budget crosses one of the budget thresholds
+func monitorBudget(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	// reached holds the highest threshold index reached per time entry, thresholds which were already reached when
+	// the monitoring of an entry begins are not notified again
+	reached := make(map[int]int)
+
+	for {
+		checkBudget(ctx, reached)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func checkBudget(ctx context.Context, reached map[int]int) {
+	if timeService == nil {
+		return
+	}
+
+	activeEntry, err := timeService.GetActiveEntry(ctx)
+	if err != nil || activeEntry == nil {
+		return
+	}
+
+	status, err := timeService.GetBudgetStatus(ctx, strconv.Itoa(activeEntry.ProjectID), time.Now())
+	if err != nil {
+		Logger().Error("Failed to get budget status", "error", err)
+		return
+	}
+	if status == nil {
+		return
+	}
+
+	level := -1
+	for i, threshold := range budgetThresholds {
+		if status.UsedRatio() >= threshold {
+			level = i
+		}
+	}
+
+	previous, seen := reached[activeEntry.ID]
+	reached[activeEntry.ID] = level
+	if !seen || level <= previous {
+		return
+	}
+
+	message := fmt.Sprintf("Project %s has used %.0f%% of its budget", status.Project.Name, status.UsedRatio()*100)
+	if status.Exceeded() {
+		message = fmt.Sprintf("Project %s is over its budget", status.Project.Name)
+	}
+
+	Logger().Warn(
+		"Budget threshold reached",
+		"project", status.Project.Name,
+		"used", status.Used.String(),
+		"budget", status.Budget.String(),
+		"threshold", budgetThresholds[level],
+	)
+
+	notify("hora", message)
+}
+
+// notify shows a desktop notification, which is only supported on macOS for now
+func notify(title string, message string) {
+	if runtime.GOOS != "darwin" {
+		return
+	}
+
+	script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+	if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+		Logger().Error("Failed to show notification", "error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package backgroundtracker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nitschmann/hora/internal/service"
+)
+
+// monitorCompliance periodically checks the work of the current day against the given rules while an entry is active
+// and notifies once per day and violated rule
+func monitorCompliance(ctx context.Context, rules service.ComplianceRules) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	// notified holds the already notified violations by day and rule
+	notified := make(map[string]bool)
+
+	for {
+		checkCompliance(ctx, rules, notified)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func checkCompliance(ctx context.Context, rules service.ComplianceRules, notified map[string]bool) {
+	if timeService == nil {
+		return
+	}
+
+	activeEntry, err := timeService.GetActiveEntry(ctx)
+	if err != nil || activeEntry == nil {
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	violations, err := timeService.CheckCompliance(ctx, rules, &today, now)
+	if err != nil {
+		Logger().Error("Failed to check compliance", "error", err)
+		return
+	}
+
+	for _, violation := range violations {
+		key := violation.Date.Format(time.DateOnly) + "/" + violation.Rule
+		if notified[key] {
+			continue
+		}
+		notified[key] = true
+
+		var message string
+		switch violation.Rule {
+		case service.ComplianceRuleBreak:
+			message = fmt.Sprintf("You worked %s today, take a break of at least %s", formatMinutes(violation.Worked), formatMinutes(violation.Required))
+		case service.ComplianceRuleRest:
+			message = fmt.Sprintf("Only %s of rest since your last working day, %s required", formatMinutes(violation.Actual), formatMinutes(violation.Required))
+		case service.ComplianceRuleMaxWork:
+			message = fmt.Sprintf("You worked %s today, more than the allowed %s", formatMinutes(violation.Worked), formatMinutes(violation.Required))
+		default:
+			continue
+		}
+
+		Logger().Warn(
+			"Compliance rule violated",
+			"rules", rules.Name,
+			"rule", violation.Rule,
+			"worked", violation.Worked.String(),
+			"actual", violation.Actual.String(),
+			"required", violation.Required.String(),
+		)
+
+		notify("hora", message)
+	}
+}
+
+// formatMinutes formats a duration in hours and minutes, e.g. 6h05m
+func formatMinutes(duration time.Duration) string {
+	duration = duration.Truncate(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(duration.Hours()), int(duration.Minutes())%60)
+}
@@ -83,7 +83,7 @@ func onScreenLocked() {
 
 	if timeService != nil {
 		ctx := context.Background()
-		err := timeService.PauseTracking(ctx)
+		err := timeService.PauseTracking(ctx, time.Now())
 		if err != nil {
 			Logger().Error("Failed to pause time tracking on screen lock", "error", err)
 		} else {
@@ -115,7 +115,7 @@ func onScreenUnlocked() {
 
 	if timeService != nil {
 		ctx := context.Background()
-		err := timeService.ContinueTracking(ctx)
+		err := timeService.ContinueTracking(ctx, time.Now())
 		if err != nil {
 			if !strings.Contains(err.Error(), "no active pause") {
 				Logger().Error("Failed to resume time tracking on screen unlock", "error", err)
@@ -156,7 +156,7 @@ func monitorPauseDuration(ctx context.Context) {
 			elapsed := time.Since(start)
 			Logger().Info("Monitoring pause duration...", "elapsed", elapsed.String(), "limit", pauseLimit.String())
 			if elapsed >= pauseLimit {
-				timeEntry, err := timeService.StopTracking(ctx)
+				timeEntry, err := timeService.StopTracking(ctx, time.Now(), "")
 				if err != nil {
 					Logger().Error("Failed to stop tracking after long pause", "error", err)
 				}
@@ -198,7 +198,7 @@ func Start(conf *config.Config, timeService service.TimeTracking) {
 			activeEntry, err := timeService.GetActiveEntry(ctx)
 
 			if activeEntry != nil && err == nil {
-				entry, err := timeService.StopTracking(ctx)
+				entry, err := timeService.StopTracking(ctx, time.Now(), "")
 				if err != nil {
 					Logger().ErrorContext(
 						ctx,
@@ -223,5 +223,18 @@ func Start(conf *config.Config, timeService service.TimeTracking) {
 		os.Exit(0)
 	}()
 
+	// Notify when the active entry's project runs out of budget
+	go monitorBudget(context.Background())
+
+	// Warn when the work of the day violates the statutory break and rest rules
+	if cfg.BackgroundTrackerCompliance {
+		rules, err := service.NewComplianceRules(cfg.ComplianceRules, cfg.Compliance)
+		if err != nil {
+			Logger().Error("Failed to get compliance rules", "error", err)
+		} else {
+			go monitorCompliance(context.Background(), rules)
+		}
+	}
+
 	C.startLockEventListenerHora()
 }
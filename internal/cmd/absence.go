@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAbsenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "absence",
+		Short: "Manage absences like vacation, sick leave and public holidays",
+		Long:  `Manage absences like vacation, sick leave and public holidays. Absent days count as worked for the expected work time of the work schedule, half days for half of it.`,
+	}
+
+	cmd.AddCommand(NewAbsenceAddCmd())
+	cmd.AddCommand(NewAbsenceImportCmd())
+	cmd.AddCommand(NewAbsenceListCmd())
+	cmd.AddCommand(NewAbsenceRemoveCmd())
+
+	return cmd
+}
+
+// parseDateInLocal parses a date in YYYY-MM-DD format in the local timezone
+func parseDateInLocal(value string) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q. Use YYYY-MM-DD format", value)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+func NewAbsenceAddCmd() *cobra.Command {
+	var (
+		absenceType string
+		halfDay     bool
+		note        string
+		to          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add [DATE]",
+		Short: "Add an absence",
+		Long: `Add a full or half day absence for a date (YYYY-MM-DD format). Use --to to add an absence for each day of a date range,
+days without expected work time in the work schedule (e.g. weekends) are skipped then.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			from, err := parseDateInLocal(args[0])
+			if err != nil {
+				return err
+			}
+
+			until := from
+			if to != "" {
+				until, err = parseDateInLocal(to)
+				if err != nil {
+					return fmt.Errorf("invalid --to value: %w", err)
+				}
+				if until.Before(from) {
+					return fmt.Errorf("--to must not be before the date")
+				}
+			}
+
+			var notePtr *string
+			if note = strings.TrimSpace(note); note != "" {
+				notePtr = &note
+			}
+
+			added := 0
+			for day := from; !day.After(until); day = day.AddDate(0, 0, 1) {
+				if to != "" && conf.WorkSchedule.Expected(day.Weekday()) == 0 {
+					continue
+				}
+
+				absence, err := timeService.AddAbsence(ctx, day, absenceType, halfDay, notePtr)
+				if err != nil {
+					return fmt.Errorf("failed to add absence: %w", err)
+				}
+
+				fmt.Printf("Added %s absence %d on %s\n", absence.Type, absence.ID, formatDateInLocal(absence.Date))
+				added++
+			}
+
+			if added == 0 {
+				fmt.Println("No absences added, the date range has no days with expected work time.")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&absenceType, "type", model.AbsenceTypeVacation, "Type of the absence (vacation, sick, holiday or other)")
+	cmd.Flags().BoolVar(&halfDay, "half-day", false, "Only absent for half of the day")
+	cmd.Flags().StringVar(&note, "note", "", "Note describing the absence")
+	cmd.Flags().StringVar(&to, "to", "", "Last day of a date range of absences (YYYY-MM-DD format)")
+
+	return cmd
+}
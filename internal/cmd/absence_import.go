@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/ics"
+	"github.com/nitschmann/hora/internal/model"
+)
+
+func NewAbsenceImportCmd() *cobra.Command {
+	var absenceType string
+
+	cmd := &cobra.Command{
+		Use:   "import [FILE]",
+		Short: "Import public holidays from an iCalendar (.ics) file",
+		Long: `Import the all-day events of a local iCalendar (.ics) file as absences, e.g. the public holidays of your region.
+Each day of an event becomes a full day absence with the event summary as note. Days which already have an absence of the same type are skipped, so a file can be imported again safely.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open file: %w", err)
+			}
+			defer file.Close()
+
+			events, err := ics.Parse(file)
+			if err != nil {
+				return fmt.Errorf("failed to parse calendar: %w", err)
+			}
+
+			var absences []model.Absence
+			for _, event := range events {
+				if !event.AllDay {
+					continue
+				}
+
+				var note *string
+				if event.Summary != "" {
+					summary := event.Summary
+					note = &summary
+				}
+
+				for _, day := range event.Days() {
+					absences = append(absences, model.Absence{Date: day, Type: absenceType, Note: note})
+				}
+			}
+
+			imported, err := timeService.ImportAbsences(ctx, absences)
+			if err != nil {
+				return fmt.Errorf("failed to import absences: %w", err)
+			}
+
+			fmt.Printf("Imported %d of %d days as %s absences from %s\n", imported, len(absences), absenceType, args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&absenceType, "type", model.AbsenceTypeHoliday, "Type of the imported absences (vacation, sick, holiday or other)")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewAbsenceListCmd() *cobra.Command {
+	var (
+		since     string
+		until     string
+		rangeExpr string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List absences",
+		Long:    `List all absences ordered by date, optionally limited to a date range.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			// The upper bound of the date filters is exclusive, absences are listed up to the last day
+			if untilTime != nil {
+				lastDay := untilTime.AddDate(0, 0, -1)
+				untilTime = &lastDay
+			}
+
+			absences, err := timeService.GetAbsences(ctx, sinceTime, untilTime)
+			if err != nil {
+				return fmt.Errorf("failed to get absences: %w", err)
+			}
+
+			if len(absences) == 0 {
+				fmt.Println("No absences found.")
+				return nil
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			table.Header("ID", "Date", "Day", "Type", "Half Day", "Note")
+
+			for _, absence := range absences {
+				halfDay := "no"
+				if absence.HalfDay {
+					halfDay = "yes"
+				}
+
+				note := "-"
+				if absence.Note != nil {
+					note = *absence.Note
+				}
+
+				table.Append([]string{
+					strconv.Itoa(absence.ID),
+					formatDateInLocal(absence.Date),
+					absence.Date.Weekday().String()[:3],
+					absence.Type,
+					halfDay,
+					note,
+				})
+			}
+
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only list absences since this date or expression (e.g. 2025-10-01, last month)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+
+	return cmd
+}
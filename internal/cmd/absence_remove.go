@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAbsenceRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "remove [ABSENCE_ID]",
+		Aliases: []string{"rm"},
+		Short:   "Remove an absence",
+		Long:    `Remove a single absence. Use 'hora absence list' to look up absence IDs. This action cannot be undone.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid absence ID: %s", args[0])
+			}
+
+			if !force {
+				fmt.Printf("This will delete absence %d. Are you sure? (y/N): ", id)
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					fmt.Println("Operation cancelled.")
+					return nil
+				}
+			}
+
+			ctx := cmd.Context()
+			absence, err := timeService.RemoveAbsence(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to remove absence: %w", mapCmdError(err))
+			}
+
+			fmt.Printf("Absence %d (%s on %s) has been removed.\n", absence.ID, absence.Type, formatDateInLocal(absence.Date))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
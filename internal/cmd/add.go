@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewAddCmd() *cobra.Command {
+	var (
+		from     string
+		to       string
+		category string
+		note     string
+		tags     []string
+		billable bool
+		pauses   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add [project]",
+		Short: "Add a completed time entry retroactively",
+		Long:  `Add a completed time entry for a project retroactively, e.g. if you forgot to start tracking. Pauses within the entry can be given with --pause (multiple times).`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			project := args[0]
+
+			startTime, err := parseDateTimeInLocal(from, time.Now())
+			if err != nil {
+				return fmt.Errorf("invalid --from value: %w", err)
+			}
+
+			endTime, err := parseDateTimeAfterInLocal(to, startTime)
+			if err != nil {
+				return fmt.Errorf("invalid --to value: %w", err)
+			}
+
+			// Validate category if provided
+			var categoryPtr *string
+			if category != "" {
+				if err := validateCategory(category); err != nil {
+					return fmt.Errorf("invalid category: %w", err)
+				}
+				categoryPtr = &category
+			}
+
+			tagList, err := normalizeTags(tags)
+			if err != nil {
+				return err
+			}
+
+			var notePtr *string
+			if note = strings.TrimSpace(note); note != "" {
+				notePtr = &note
+			}
+
+			var entryPauses []model.Pause
+			for _, value := range pauses {
+				pause, err := parsePauseRange(value, startTime)
+				if err != nil {
+					return fmt.Errorf("invalid --pause value: %w", err)
+				}
+				entryPauses = append(entryPauses, pause)
+			}
+
+			attrs := service.EntryAttributes{Category: categoryPtr, Notes: notePtr, Tags: tagList, NonBillable: !billable}
+			entry, err := timeService.AddEntry(ctx, project, startTime, endTime, attrs, entryPauses)
+			if err != nil {
+				return fmt.Errorf("failed to add time entry: %w", err)
+			}
+
+			fmt.Printf("Added time entry %d for project: %s\n", entry.ID, entry.Project.Name)
+			fmt.Printf("Duration: %s\n", timeService.FormatDuration(*entry.Duration))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Start time of the entry (YYYY-MM-DD HH:MM format, or HH:MM for today)")
+	cmd.Flags().StringVar(&to, "to", "", "End time of the entry (YYYY-MM-DD HH:MM format, or HH:MM relative to the start)")
+	cmd.Flags().StringVar(&category, "category", "", "Category for this time entry (alphanumeric, underscore, hyphen only)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag for this time entry, can be given multiple times (alphanumeric, underscore, hyphen only)")
+	cmd.Flags().StringVar(&note, "note", "", "Note describing the work of this time entry")
+	cmd.Flags().BoolVar(&billable, "billable", true, "Whether this time entry is billable (use --billable=false for non-billable work)")
+	cmd.Flags().StringArrayVar(&pauses, "pause", nil, "Pause within the entry (HH:MM-HH:MM format), can be given multiple times")
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func NewBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup [FILE]",
+		Short: "Write a JSON backup of all data",
+		Long: `Write a versioned JSON backup of all projects, category rates, time entries, pauses and absences including their IDs and creation times.
+The backup is written to the given file, TIMESTAMP_backup.json by default, and can be restored with 'hora restore'.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			backup, err := timeService.CreateBackup(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create backup: %w", mapCmdError(err))
+			}
+
+			filename := fmt.Sprintf("%s_backup.json", time.Now().Format("20060102150405"))
+			if len(args) > 0 {
+				filename = args[0]
+			}
+
+			data, err := json.MarshalIndent(backup, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode backup: %w", err)
+			}
+
+			if err := os.WriteFile(filename, append(data, '\n'), 0o600); err != nil {
+				return fmt.Errorf("failed to write backup: %w", err)
+			}
+
+			fmt.Printf("Backed up %d projects, %d time entries, %d pauses and %d absences to %s\n",
+				len(backup.Projects), len(backup.TimeEntries), len(backup.Pauses), len(backup.Absences), filename)
+			return nil
+		},
+	}
+
+	return cmd
+}
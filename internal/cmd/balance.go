@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewBalanceCmd() *cobra.Command {
+	var (
+		since     string
+		until     string
+		rangeExpr string
+		weekly    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "balance",
+		Short: "Show the overtime balance against the work schedule",
+		Long: `Compare the expected work time of the configured work schedule with the effective work time (duration minus pauses) per day and week,
+and show the cumulative overtime or undertime balance. Absences like vacation or public holidays credit the expected work time of their days. The schedule and the first day of the week are set with 'work_schedule' and 'week_start' in the configuration.
+Without --since or --range, the balance starts with the first tracked day. It ends today unless --until or --range is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			// The upper bound of the date filters is exclusive, the balance includes its last day
+			lastDay := time.Now()
+			if untilTime != nil {
+				lastDay = untilTime.AddDate(0, 0, -1)
+			}
+
+			balance, err := timeService.GetBalance(ctx, conf.WorkSchedule, conf.WeekStartDay(), sinceTime, lastDay)
+			if err != nil {
+				return fmt.Errorf("failed to get balance: %w", err)
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			var cumulative time.Duration
+
+			if weekly {
+				table.Header("Week", "Expected", "Worked", "Absences", "Difference", "Balance")
+				for _, week := range balance.Weeks {
+					cumulative += week.Difference()
+					table.Append([]string{
+						formatDateInLocal(week.Start),
+						timeService.FormatDuration(week.Expected),
+						timeService.FormatDuration(week.Worked),
+						timeService.FormatDuration(week.Credited),
+						formatSignedDuration(week.Difference()),
+						formatSignedDuration(cumulative),
+					})
+				}
+			} else {
+				table.Header("Date", "Day", "Expected", "Worked", "Absences", "Difference", "Balance")
+				for _, week := range balance.Weeks {
+					for _, day := range week.Days {
+						cumulative += day.Difference()
+						table.Append([]string{
+							formatDateInLocal(day.Date),
+							day.Date.Weekday().String()[:3],
+							timeService.FormatDuration(day.Expected),
+							timeService.FormatDuration(day.Worked),
+							formatDayAbsences(day),
+							formatSignedDuration(day.Difference()),
+							formatSignedDuration(cumulative),
+						})
+					}
+
+					table.Append([]string{
+						"Week total",
+						"",
+						timeService.FormatDuration(week.Expected),
+						timeService.FormatDuration(week.Worked),
+						timeService.FormatDuration(week.Credited),
+						formatSignedDuration(week.Difference()),
+						formatSignedDuration(cumulative),
+					})
+				}
+			}
+
+			table.Render()
+
+			fmt.Printf("Expected: %s\n", timeService.FormatDuration(balance.Expected))
+			fmt.Printf("Worked: %s\n", timeService.FormatDuration(balance.Worked))
+			if balance.Credited > 0 {
+				fmt.Printf("Credited by absences: %s\n", timeService.FormatDuration(balance.Credited))
+			}
+
+			switch difference := balance.Difference(); {
+			case difference > 0:
+				fmt.Printf("Balance: %s (overtime)\n", formatSignedDuration(difference))
+			case difference < 0:
+				fmt.Printf("Balance: %s (undertime)\n", formatSignedDuration(difference))
+			default:
+				fmt.Printf("Balance: %s\n", formatSignedDuration(difference))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Start the balance at this date or expression (e.g. 2025-10-01, last month)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	cmd.Flags().BoolVar(&weekly, "weekly", false, "Only show one row per week")
+
+	return cmd
+}
+
+// formatDayAbsences formats the absences of a day together with the credited work time
+func formatDayAbsences(day service.DayBalance) string {
+	if len(day.Absences) == 0 {
+		return "-"
+	}
+
+	types := make([]string, 0, len(day.Absences))
+	for _, absence := range day.Absences {
+		if absence.HalfDay {
+			types = append(types, absence.Type+" (half)")
+		} else {
+			types = append(types, absence.Type)
+		}
+	}
+
+	return fmt.Sprintf("%s %s", strings.Join(types, ", "), timeService.FormatDuration(day.Credited))
+}
+
+// formatSignedDuration formats a duration with a leading sign, e.g. +01:30:00 or -00:45:00
+func formatSignedDuration(duration time.Duration) string {
+	if duration < 0 {
+		return "-" + timeService.FormatDuration(-duration)
+	}
+
+	return "+" + timeService.FormatDuration(duration)
+}
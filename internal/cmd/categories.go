@@ -6,6 +6,8 @@ import (
 	"sort"
 
 	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/output"
 )
 
 func NewCategoriesCmd() *cobra.Command {
@@ -20,17 +22,28 @@ func NewCategoriesCmd() *cobra.Command {
 				return fmt.Errorf("failed to get categories: %w", err)
 			}
 
-			if len(categories) == 0 {
-				fmt.Println("No categories found.")
+			sort.Strings(categories)
+
+			// The default output stays a plain list, one category per line
+			if outputFormat == output.FormatTable {
+				if len(categories) == 0 {
+					fmt.Println("No categories found.")
+					return nil
+				}
+
+				for _, category := range categories {
+					fmt.Println(category)
+				}
+
 				return nil
 			}
 
-			sort.Strings(categories)
+			table := &output.Table{Header: []string{"Category"}}
 			for _, category := range categories {
-				fmt.Println(category)
+				table.Append([]string{category})
 			}
 
-			return nil
+			return renderOutput(cmd, categories, table)
 		},
 	}
 
@@ -1,15 +1,16 @@
 package cmd
 
 import (
-	"encoding/csv"
+	"context"
 	"fmt"
-	"os"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/nitschmann/hora/internal/database"
+	"github.com/nitschmann/hora/internal/daterange"
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/output"
 	"github.com/nitschmann/hora/internal/repository"
 	"github.com/nitschmann/hora/internal/service"
 	"github.com/spf13/cobra"
@@ -18,6 +19,20 @@ import (
 var (
 	dbConn      *database.Connection
 	timeService service.TimeTracking
+
+	// dateTimeLayouts defines the accepted layouts for full date and time flag values
+	dateTimeLayouts = []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		time.RFC3339,
+	}
+	// clockLayouts defines the accepted layouts for flag values which only contain a time of day
+	clockLayouts = []string{
+		"15:04:05",
+		"15:04",
+	}
 )
 
 // addListCommandCommonFlags adds common flags for lists to the given cobra command
@@ -27,92 +42,91 @@ func addListCommandCommonFlags(cmd *cobra.Command,
 	sortVar *string,
 ) {
 	cmd.Flags().IntVarP(limitVar, "limit", "l", conf.ListLimit, "Maximum number of entries to show")
-	cmd.Flags().StringVar(sinceVar, "since", "", "Only show entries since this date (YYYY-MM-DD format)")
+	cmd.Flags().StringVar(sinceVar, "since", "", "Only show entries since this date or expression (e.g. 2025-10-16, yesterday, last week, 2h ago)")
 	cmd.Flags().StringVar(sortVar, "sort", conf.ListOrder, "Sort order: 'asc' (oldest first) or 'desc' (newest first)")
 }
 
-// exportTimesToCSV exports the given time entries to a CSV file with the specified filename
-func exportTimesToCSV(entries []repository.TimeEntryWithPauses, filename string, project string) (string, error) {
-	if filename == "" {
-		timestamp := time.Now().Format("20060102150405")
-		filename = fmt.Sprintf("%s_times.csv", timestamp)
-	}
+// addDateRangeFlags adds the flags for an upper bound and a range of the start time to the given cobra command
+func addDateRangeFlags(cmd *cobra.Command, untilVar *string, rangeVar *string) {
+	cmd.Flags().StringVar(untilVar, "until", "", "Only show entries until this date or expression, inclusive (e.g. 2025-10-31, yesterday, last month)")
+	cmd.Flags().StringVar(rangeVar, "range", "", "Only show entries within this date expression or range (e.g. last week, 2025-W42, 2025-10-01..2025-10-15)")
+}
 
-	file, err := os.Create(filename)
-	if err != nil {
-		return filename, err
-	}
-	defer file.Close()
+// parseDateFilters parses the values of the --since, --until and --range flags into the optional inclusive lower and
+// exclusive upper bound of the start time of entries. Dates and expressions like "last week" cover their whole
+// period, so --until includes the given day.
+func parseDateFilters(since string, until string, rangeExpr string) (*time.Time, *time.Time, error) {
+	now := time.Now()
+	weekStart := conf.WeekStartDay()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	if rangeExpr != "" {
+		if since != "" || until != "" {
+			return nil, nil, fmt.Errorf("--range cannot be used together with --since or --until")
+		}
 
-	header := []string{
-		"Start Time",
-		"End Time",
-		"Project",
-		"Category",
-		"Duration",
-		"Pauses",
-		"Pause Time",
-		"Effective Work Time",
-	}
-	if err := writer.Write(header); err != nil {
-		return filename, err
+		r, err := daterange.ParseRange(rangeExpr, now, weekStart)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --range value: %w", err)
+		}
+
+		return &r.Start, &r.End, nil
 	}
 
-	for _, entry := range entries {
-		startTime := formatTimeInLocal(entry.StartTime)
-		endTime := "-"
-		if entry.EndTime != nil {
-			endTime = formatTimeInLocal(*entry.EndTime)
+	var sinceTime, untilTime *time.Time
+	if since != "" {
+		r, err := daterange.Parse(since, now, weekStart)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --since value: %w", err)
 		}
+		sinceTime = &r.Start
+	}
 
-		rowProject := project
-		if rowProject == "" {
-			rowProject = entry.Project.Name
+	if until != "" {
+		r, err := daterange.Parse(until, now, weekStart)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --until value: %w", err)
 		}
+		untilTime = &r.End
+	}
 
-		category := "-"
-		if entry.Category != nil {
-			category = *entry.Category
-		}
+	if sinceTime != nil && untilTime != nil && untilTime.Before(*sinceTime) {
+		return nil, nil, fmt.Errorf("--until must not be before --since")
+	}
 
-		duration := "-"
-		if entry.Duration != nil {
-			duration = formatDuration(*entry.Duration)
-		}
+	return sinceTime, untilTime, nil
+}
 
-		pauseTime := "-"
-		if entry.PauseTime > 0 {
-			pauseTime = formatDuration(entry.PauseTime)
-		}
+// addRawFlag adds the flag to show the exact work time without rounding to the given cobra command
+func addRawFlag(cmd *cobra.Command, rawVar *bool) {
+	cmd.Flags().BoolVar(rawVar, "raw", false, "Show the exact work time without applying the rounding rules")
+}
 
-		effectiveTime := "-"
-		if entry.Duration != nil {
-			effective := *entry.Duration - entry.PauseTime
-			if effective > 0 {
-				effectiveTime = formatDuration(effective)
-			}
-		}
+// configuredRounding returns the rounding of the configuration
+func configuredRounding() model.Rounding {
+	return model.Rounding{
+		Mode:      conf.Rounding.Mode,
+		Increment: time.Duration(conf.Rounding.Increment) * time.Minute,
+		Scope:     conf.Rounding.Scope,
+	}
+}
 
-		record := []string{
-			startTime,
-			endTime,
-			rowProject,
-			category,
-			duration,
-			strconv.Itoa(entry.PauseCount),
-			pauseTime,
-			effectiveTime,
-		}
+// getRoundingRules returns the rounding rules of all projects, or rules which do not round at all if raw is set
+func getRoundingRules(ctx context.Context, raw bool) (*service.RoundingRules, error) {
+	if raw {
+		return service.NewRoundingRules(model.Rounding{Mode: model.RoundingModeNone}), nil
+	}
 
-		if err := writer.Write(record); err != nil {
-			return filename, err
-		}
+	rules, err := timeService.GetRoundingRules(ctx, configuredRounding())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rounding rules: %w", err)
 	}
 
-	return filename, nil
+	return rules, nil
+}
+
+// renderOutput renders a list or report in the format of the global --output flag
+func renderOutput(cmd *cobra.Command, value any, table *output.Table) error {
+	return output.Render(cmd.OutOrStdout(), outputFormat, value, table)
 }
 
 // formatTimeInLocal formats a time value in the local timezone
@@ -130,6 +144,16 @@ func formatDateInLocal(t time.Time) string {
 	return t.Local().Format("2006-01-02")
 }
 
+// formatRoundedDuration formats the rounded work time of an entry, or "-" if the entry has none
+func formatRoundedDuration(rounded map[int]time.Duration, entryID int) string {
+	duration, ok := rounded[entryID]
+	if !ok {
+		return "-"
+	}
+
+	return formatDuration(duration)
+}
+
 // formatDuration formats a duration as HH:MM:SS
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
@@ -138,6 +162,87 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 }
 
+// parseDateTimeInLocal parses a date and time value in the local timezone.
+// Values which only contain a time of day (e.g. 09:30) are resolved against the date of the reference time.
+func parseDateTimeInLocal(value string, reference time.Time) (time.Time, error) {
+	t, _, err := parseDateTimeOrClockInLocal(value, reference)
+	return t, err
+}
+
+// parseDateTimeAfterInLocal works like parseDateTimeInLocal, but moves values which only contain a time of day to the
+// next day if they would be before the reference time (e.g. for entries spanning midnight)
+func parseDateTimeAfterInLocal(value string, reference time.Time) (time.Time, error) {
+	t, isClock, err := parseDateTimeOrClockInLocal(value, reference)
+	if err != nil {
+		return t, err
+	}
+
+	if isClock && t.Before(reference) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
+// parseDateTimeOrClockInLocal parses a date and time or time of day value and reports if it only was a time of day
+func parseDateTimeOrClockInLocal(value string, reference time.Time) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, false, nil
+		}
+	}
+
+	for _, layout := range clockLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			reference = reference.Local()
+			return time.Date(reference.Year(), reference.Month(), reference.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), true, nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid time %q. Use YYYY-MM-DD HH:MM or HH:MM format", value)
+}
+
+// parseAtFlag parses the value of an --at flag, falling back to the current time if no value is given
+func parseAtFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	t, err := parseDateTimeInLocal(value, time.Now())
+	if err != nil {
+		return t, fmt.Errorf("invalid --at value: %w", err)
+	}
+
+	return t, nil
+}
+
+// parsePauseRange parses a pause range in START-END format (e.g. 12:00-12:30) into a completed pause.
+// Both times are resolved relative to the start time of the time entry the pause belongs to.
+func parsePauseRange(value string, entryStart time.Time) (model.Pause, error) {
+	// full date values contain dashes themselves, so try every dash as separator
+	for i, r := range value {
+		if r != '-' {
+			continue
+		}
+
+		pauseStart, err := parseDateTimeAfterInLocal(value[:i], entryStart)
+		if err != nil {
+			continue
+		}
+
+		pauseEnd, err := parseDateTimeAfterInLocal(value[i+1:], pauseStart)
+		if err != nil {
+			continue
+		}
+
+		return model.Pause{PauseStart: pauseStart, PauseEnd: &pauseEnd}, nil
+	}
+
+	return model.Pause{}, fmt.Errorf("invalid pause %q. Use HH:MM-HH:MM format", value)
+}
+
 func initDatabaseConnectionAndService() error {
 	var err error
 	dbConn, err = database.NewConnection(conf)
@@ -149,31 +254,80 @@ func initDatabaseConnectionAndService() error {
 	projectRepo := repository.NewProject(dbConn.GetDB())
 	timeEntryRepo := repository.NewTimeEntry(dbConn.GetDB())
 	pauseRepo := repository.NewPause(dbConn.GetDB())
+	tagRepo := repository.NewTag(dbConn.GetDB())
+	absenceRepo := repository.NewAbsence(dbConn.GetDB())
+	transactor := repository.NewTransactor(dbConn.GetDB())
 
-	timeService = service.NewTimeTracking(projectRepo, timeEntryRepo, pauseRepo)
+	timeService = service.NewTimeTracking(projectRepo, timeEntryRepo, pauseRepo, tagRepo, absenceRepo, transactor)
 
 	return err
 }
 
 // validateCategory validates that a category contains only alphanumeric characters, underscores, and hyphens
 func validateCategory(category string) error {
-	if category == "" {
+	return validateName("category", category)
+}
+
+// validateTag validates that a tag contains only alphanumeric characters, underscores, and hyphens
+func validateTag(tag string) error {
+	return validateName("tag", tag)
+}
+
+// normalizeTags validates the given tags and removes empty values and duplicates
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if err := validateTag(tag); err != nil {
+			return nil, fmt.Errorf("invalid tag: %w", err)
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized, nil
+}
+
+// validateName validates that a category or tag name contains only alphanumeric characters, underscores, and hyphens
+func validateName(kind string, name string) error {
+	if name == "" {
 		return nil
 	}
 
 	// Check for common shell special characters that might cause issues
-	if strings.ContainsAny(category, "!$`\\") {
-		return fmt.Errorf("category contains shell special characters (!$`\\) that may cause issues. Use only alphanumeric characters, underscores (_), and hyphens (-)")
+	if strings.ContainsAny(name, "!$`\\") {
+		return fmt.Errorf("%s contains shell special characters (!$`\\) that may cause issues. Use only alphanumeric characters, underscores (_), and hyphens (-)", kind)
 	}
 
-	matched, err := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, category)
+	matched, err := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, name)
 	if err != nil {
-		return fmt.Errorf("failed to validate category: %w", err)
+		return fmt.Errorf("failed to validate %s: %w", kind, err)
 	}
 
 	if !matched {
-		return fmt.Errorf("category must contain only alphanumeric characters, underscores (_), and hyphens (-)")
+		return fmt.Errorf("%s must contain only alphanumeric characters, underscores (_), and hyphens (-)", kind)
 	}
 
 	return nil
 }
+
+// normalizeCurrency validates that a currency is a three letter ISO 4217 code and returns it in upper case
+func normalizeCurrency(currency string) (string, error) {
+	if currency == "" {
+		return "", nil
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if matched, _ := regexp.MatchString(`^[A-Z]{3}$`, currency); !matched {
+		return "", fmt.Errorf("currency must be a three letter code like EUR or USD")
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewComplianceCmd() *cobra.Command {
+	var (
+		since     string
+		until     string
+		rangeExpr string
+		ruleSet   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compliance",
+		Short: "Check the tracked work against statutory break and rest rules",
+		Long: `Check the tracked work of each day against a statutory rule set and list the violations.
+The rule sets check the minimum breaks for the daily work time, the minimum rest between working days and the maximum daily work time.
+Pauses and gaps between the entries of a day count as breaks if they are long enough.
+
+Available rule sets:
+  de  Germany: 30 minutes break after 6 hours and 45 minutes after 9 hours of work (in parts of at least 15 minutes),
+      11 hours of rest, at most 10 hours of work per day
+  at  Austria: 30 minutes break after 6 hours of work (in parts of at least 10 minutes), 11 hours of rest,
+      at most 12 hours of work per day
+
+The default rule set is set with 'compliance_rules' in the configuration, the thresholds of the rule set can be
+overridden in the 'compliance' section of the configuration.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if ruleSet == "" {
+				ruleSet = conf.ComplianceRules
+			}
+
+			rules, err := service.NewComplianceRules(ruleSet, conf.Compliance)
+			if err != nil {
+				return err
+			}
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			// The upper bound of the date filters is exclusive, active entries count until now
+			checkUntil := time.Now()
+			if untilTime != nil && untilTime.Before(checkUntil) {
+				checkUntil = untilTime.Add(-time.Nanosecond)
+			}
+
+			violations, err := timeService.CheckCompliance(ctx, rules, sinceTime, checkUntil)
+			if err != nil {
+				return fmt.Errorf("failed to check compliance: %w", err)
+			}
+
+			if len(violations) == 0 {
+				fmt.Printf("No violations of the '%s' rules found.\n", rules.Name)
+				return nil
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			table.Header("Date", "Day", "Rule", "Worked", "Violation")
+
+			for _, violation := range violations {
+				table.Append([]string{
+					formatDateInLocal(violation.Date),
+					violation.Date.Weekday().String()[:3],
+					violation.Rule,
+					timeService.FormatDuration(violation.Worked),
+					formatComplianceViolation(violation),
+				})
+			}
+
+			table.Render()
+
+			fmt.Printf("%d violation(s) of the '%s' rules found.\n", len(violations), rules.Name)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only check days since this date or expression (e.g. 2025-10-01, last week)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	cmd.Flags().StringVar(&ruleSet, "rules", "", "Rule set to check against (de, at), defaults to the configured rule set")
+
+	return cmd
+}
+
+// formatComplianceViolation describes a violation with its actual and required time
+func formatComplianceViolation(violation service.ComplianceViolation) string {
+	switch violation.Rule {
+	case service.ComplianceRuleBreak:
+		return fmt.Sprintf(
+			"%s break, %s required",
+			timeService.FormatDuration(violation.Actual),
+			timeService.FormatDuration(violation.Required),
+		)
+	case service.ComplianceRuleRest:
+		return fmt.Sprintf(
+			"%s rest since the previous working day, %s required",
+			timeService.FormatDuration(violation.Actual),
+			timeService.FormatDuration(violation.Required),
+		)
+	case service.ComplianceRuleMaxWork:
+		return fmt.Sprintf(
+			"%s work, at most %s allowed",
+			timeService.FormatDuration(violation.Actual),
+			timeService.FormatDuration(violation.Required),
+		)
+	default:
+		return "-"
+	}
+}
@@ -7,13 +7,21 @@ import (
 )
 
 func NewContinueCmd() *cobra.Command {
+	var at string
+
 	cmd := &cobra.Command{
 		Use:   "continue",
 		Short: "Continue the currently paused time tracking session",
 		Long:  `Continue the currently paused time tracking session. This will end the current pause and resume tracking.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			err := timeService.ContinueTracking(ctx)
+
+			pauseEnd, err := parseAtFlag(at)
+			if err != nil {
+				return err
+			}
+
+			err = timeService.ContinueTracking(ctx, pauseEnd)
 			if err != nil {
 				return fmt.Errorf("failed to continue tracking: %w", err)
 			}
@@ -23,5 +31,7 @@ func NewContinueCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&at, "at", "", "Continue at this time instead of now (YYYY-MM-DD HH:MM format, or HH:MM for today)")
+
 	return cmd
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewEditCmd() *cobra.Command {
+	var (
+		project  string
+		start    string
+		end      string
+		category string
+		tags     []string
+		billable bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit [TIME_ENTRY_ID]",
+		Short: "Edit an existing time entry",
+		Long:  `Edit the project, start time, end time, category, tags or billable flag of an existing time entry. The work duration is recomputed from the entry's pauses. Use 'hora times' to look up entry IDs.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid time entry ID: %s", args[0])
+			}
+
+			entry, err := timeService.GetEntryByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get time entry: %w", mapCmdError(err))
+			}
+
+			var changes service.TimeEntryChanges
+
+			if cmd.Flags().Changed("project") {
+				changes.ProjectName = &project
+			}
+
+			// Times of day are resolved against the date of the entry
+			startTime := entry.StartTime
+			if cmd.Flags().Changed("start") {
+				startTime, err = parseDateTimeInLocal(start, entry.StartTime)
+				if err != nil {
+					return fmt.Errorf("invalid --start value: %w", err)
+				}
+				changes.StartTime = &startTime
+			}
+
+			if cmd.Flags().Changed("end") {
+				endTime, err := parseDateTimeInLocal(end, startTime)
+				if err != nil {
+					return fmt.Errorf("invalid --end value: %w", err)
+				}
+				changes.EndTime = &endTime
+			}
+
+			if cmd.Flags().Changed("category") {
+				if err := validateCategory(category); err != nil {
+					return fmt.Errorf("invalid category: %w", err)
+				}
+				changes.Category = &category
+			}
+
+			if cmd.Flags().Changed("tag") {
+				changes.Tags, err = normalizeTags(tags)
+				if err != nil {
+					return err
+				}
+			}
+
+			if cmd.Flags().Changed("billable") {
+				changes.Billable = &billable
+			}
+
+			if changes.ProjectName == nil && changes.StartTime == nil && changes.EndTime == nil && changes.Category == nil &&
+				changes.Tags == nil && changes.Billable == nil {
+				return fmt.Errorf("nothing to edit. Use --project, --start, --end, --category, --tag or --billable")
+			}
+
+			entry, err = timeService.EditEntry(ctx, id, changes)
+			if err != nil {
+				return fmt.Errorf("failed to edit time entry: %w", err)
+			}
+
+			fmt.Printf("Updated time entry %d for project: %s\n", entry.ID, entry.Project.Name)
+			if entry.Duration != nil {
+				fmt.Printf("Duration: %s\n", timeService.FormatDuration(*entry.Duration))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&project, "project", "", "Move the entry to this project")
+	cmd.Flags().StringVar(&start, "start", "", "New start time (YYYY-MM-DD HH:MM format, or HH:MM on the entry's date)")
+	cmd.Flags().StringVar(&end, "end", "", "New end time (YYYY-MM-DD HH:MM format, or HH:MM on the date of the start)")
+	cmd.Flags().StringVar(&category, "category", "", "New category (an empty value removes the category)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "New tags replacing the existing ones, can be given multiple times (an empty value removes all tags)")
+	cmd.Flags().BoolVar(&billable, "billable", true, "Mark the entry as billable (use --billable=false for non-billable work)")
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewEntryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "entry",
+		Aliases: []string{"e"},
+		Short:   "Manage individual time entries",
+		Long:    `Manage individual time entries in your time tracking system.`,
+	}
+
+	cmd.AddCommand(NewEntryRemoveCmd())
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/backgroundtracker"
+)
+
+func NewEntryRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "remove [TIME_ENTRY_ID]",
+		Aliases: []string{"rm"},
+		Short:   "Remove a single time entry and its pauses",
+		Long:    `Remove a single time entry and all its pauses. This action cannot be undone. Use 'hora times' to look up entry IDs.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid time entry ID: %s", args[0])
+			}
+
+			if !force {
+				fmt.Printf("This will delete time entry %d and ALL its pauses. Are you sure? (y/N): ", id)
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					fmt.Println("Operation cancelled.")
+					return nil
+				}
+			}
+
+			ctx := cmd.Context()
+			entry, err := timeService.RemoveEntry(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to remove time entry: %w", mapCmdError(err))
+			}
+
+			fmt.Printf("Time entry %d of project '%s' has been removed.\n", entry.ID, entry.Project.Name)
+
+			// There is nothing left to track for the background tracker
+			if entry.EndTime == nil && backgroundtracker.IsRunning() {
+				if err := backgroundtracker.Stop(); err != nil {
+					fmt.Printf("Warning: Failed to stop background tracker: %v\n", err)
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
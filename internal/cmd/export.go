@@ -3,40 +3,71 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"time"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/nitschmann/hora/internal/model"
 	"github.com/nitschmann/hora/internal/repository"
 )
 
+// Supported export formats
+const (
+	exportFormatCSV       = "csv"
+	exportFormatICS       = "ics"
+	exportFormatTimeclock = "timeclock"
+	exportFormatXLSX      = "xlsx"
+)
+
+// exportFormats lists all supported export formats
+var exportFormats = []string{exportFormatCSV, exportFormatICS, exportFormatTimeclock, exportFormatXLSX}
+
 func NewExportCmd() *cobra.Command {
 	var (
-		category string
-		limit    int
-		since    string
-		sort     string
-		output   string
+		category  string
+		tags      []string
+		allTags   bool
+		limit     int
+		since     string
+		until     string
+		rangeExpr string
+		sort      string
+		file      string
+		format    string
+		profile   string
+		raw       bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "export",
-		Short: "Export time entries to CSV",
-		Long:  "Export all time entries across projects to a CSV file",
+		Short: "Export time entries to CSV, Excel, iCalendar or timeclock",
+		Long: `Export all time entries across projects to a CSV, Excel (.xlsx), iCalendar (.ics) or timeclock file.
+The Excel export has a sheet per project with the columns of the CSV export and a summary sheet which sums them up with formulas.
+Times are date and time cells and durations are time values, so they can be used in calculations.
+The iCalendar export contains an event per time entry with the project as summary and the category, tags, notes and pauses in the description.
+The events keep their UIDs across exports, so importing a newer export into a calendar updates the events instead of duplicating them.
+The timeclock export can be read by hledger and ledger. Project and category form the account (e.g. Client:dev) and the notes the description.
+Each pause checks the clock out and in again, so the totals match the effective work time. Running entries are not exported.
+With --profile the CSV export uses the columns, delimiter, time zone, time layout and duration format of a profile in export_profiles of the configuration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var (
-				err       error
-				sinceTime *time.Time
-			)
-
 			ctx := context.Background()
 
-			if since != "" {
-				parsed, err := time.Parse("2006-01-02", since)
-				if err != nil {
-					return fmt.Errorf("invalid date format: %w", err)
-				}
-				sinceTime = &parsed
+			if !slices.Contains(exportFormats, format) {
+				return fmt.Errorf("invalid export format %q, must be one of: %s", format, strings.Join(exportFormats, ", "))
+			}
+
+			if profile != "" && format != exportFormatCSV {
+				return fmt.Errorf("--profile can only be used with the %s format", exportFormatCSV)
+			}
+			csvProfile, err := getExportProfile(profile)
+			if err != nil {
+				return err
+			}
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
 			}
 
 			var categoryPtr *string
@@ -47,19 +78,57 @@ func NewExportCmd() *cobra.Command {
 				categoryPtr = &category
 			}
 
-			var entries []repository.TimeEntryWithPauses
-			if categoryPtr != nil {
-				entries, err = timeService.GetAllEntriesWithPausesByCategory(ctx, limit, sort, sinceTime, categoryPtr)
-			} else {
-				entries, err = timeService.GetAllEntriesWithPauses(ctx, limit, sort, sinceTime)
+			tagList, err := normalizeTags(tags)
+			if err != nil {
+				return err
 			}
+
+			entries, err := timeService.GetAllEntriesWithPausesFiltered(ctx, limit, sort, repository.TimeEntryFilter{
+				Since:        sinceTime,
+				Until:        untilTime,
+				Category:     categoryPtr,
+				Tags:         tagList,
+				MatchAllTags: allTags,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to get time entries: %w", err)
 			}
 
-			filename, err := exportTimesToCSV(entries, output, "")
-			if err != nil {
-				return fmt.Errorf("failed to export CSV: %w", err)
+			var filename string
+			switch format {
+			case exportFormatICS:
+				filename, err = exportTimesToICS(ctx, entries, file)
+				if err != nil {
+					return fmt.Errorf("failed to export iCalendar: %w", err)
+				}
+			case exportFormatTimeclock:
+				filename, err = exportTimesToTimeclock(ctx, entries, file)
+				if err != nil {
+					return fmt.Errorf("failed to export timeclock: %w", err)
+				}
+			default:
+				rates, err := timeService.GetBillingRates(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get billing rates: %w", err)
+				}
+
+				rounding, err := getRoundingRules(ctx, raw)
+				if err != nil {
+					return err
+				}
+
+				if format == exportFormatXLSX {
+					filename, err = exportTimesToXLSX(entries, rates, rounding, file)
+					if err != nil {
+						return fmt.Errorf("failed to export Excel workbook: %w", err)
+					}
+					break
+				}
+
+				filename, err = exportTimesToCSV(entries, rates, rounding, file, "", csvProfile)
+				if err != nil {
+					return fmt.Errorf("failed to export CSV: %w", err)
+				}
 			}
 
 			fmt.Printf("Exported %d time entries to %s\n", len(entries), filename)
@@ -69,9 +138,31 @@ func NewExportCmd() *cobra.Command {
 	}
 
 	addListCommandCommonFlags(cmd, &limit, &since, &sort)
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	addRawFlag(cmd, &raw)
 
 	cmd.Flags().StringVar(&category, "category", "", "Filter by category")
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path (default: TIMESTAMP_times.csv)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Filter by tag, can be given multiple times (entries with any of the tags)")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Only export entries which have all of the given tags")
+	cmd.Flags().StringVarP(&file, "file", "o", "", "Output file path (default: TIMESTAMP_times with the extension of the format)")
+	cmd.Flags().StringVar(&format, "format", exportFormatCSV, fmt.Sprintf("Format of the exported file (%s)", strings.Join(exportFormats, ", ")))
+	addExportProfileFlag(cmd, &profile)
 
 	return cmd
 }
+
+// completedPauses returns the completed pauses of a time entry
+func completedPauses(ctx context.Context, entry repository.TimeEntryWithPauses) ([]model.Pause, error) {
+	if entry.PauseCount == 0 {
+		return nil, nil
+	}
+
+	pauses, err := timeService.GetPausesForEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pauses of time entry %d: %w", entry.ID, err)
+	}
+
+	return slices.DeleteFunc(pauses, func(pause model.Pause) bool {
+		return pause.PauseEnd == nil
+	}), nil
+}
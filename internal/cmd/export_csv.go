@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/config"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/nitschmann/hora/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Duration formats of export profiles, durations are formatted as HH:MM:SS by default
+const (
+	durationFormatDecimal = "decimal"
+	durationFormatMinutes = "minutes"
+	durationFormatSeconds = "seconds"
+)
+
+// defaultExportColumns are the columns of the default CSV export
+var defaultExportColumns = []string{
+	"start_time",
+	"end_time",
+	"project",
+	"category",
+	"duration",
+	"pauses",
+	"pause_time",
+	"work_time",
+	"rounded_work_time",
+	"notes",
+	"tags",
+	"billable",
+	"amount",
+	"currency",
+}
+
+// exportHeader is the header of the exported time entries
+var exportHeader = exportColumnHeaders(defaultExportColumns)
+
+// exportRecord holds the exported values of a time entry. Values the entry does not have, e.g. the end of a running
+// entry, are nil.
+type exportRecord struct {
+	ID        int
+	ProjectID int
+	StartTime time.Time
+	EndTime   *time.Time
+	Project   string
+	Category  *string
+	// Duration is the total duration including the pauses
+	Duration        *time.Duration
+	Pauses          int
+	PauseTime       *time.Duration
+	WorkTime        *time.Duration
+	RoundedWorkTime *time.Duration
+	Notes           *string
+	Tags            []string
+	Billable        bool
+	Amount          *float64
+	Currency        string
+}
+
+// csvFormat defines how the times and durations of a CSV export are formatted
+type csvFormat struct {
+	location       *time.Location
+	timeLayout     string
+	durationFormat string
+}
+
+// exportColumn is a column which export profiles can select
+type exportColumn struct {
+	header string
+	value  func(record exportRecord, format csvFormat) string
+}
+
+// exportColumns maps the names of the columns of export profiles to their header and value
+var exportColumns = map[string]exportColumn{
+	"id": {"ID", func(r exportRecord, f csvFormat) string {
+		return strconv.Itoa(r.ID)
+	}},
+	"project_id": {"Project ID", func(r exportRecord, f csvFormat) string {
+		return strconv.Itoa(r.ProjectID)
+	}},
+	"project": {"Project", func(r exportRecord, f csvFormat) string {
+		return r.Project
+	}},
+	"start_time": {"Start Time", func(r exportRecord, f csvFormat) string {
+		return f.time(&r.StartTime)
+	}},
+	"end_time": {"End Time", func(r exportRecord, f csvFormat) string {
+		return f.time(r.EndTime)
+	}},
+	"category": {"Category", func(r exportRecord, f csvFormat) string {
+		return optionalString(r.Category)
+	}},
+	"duration": {"Duration", func(r exportRecord, f csvFormat) string {
+		return f.duration(r.Duration)
+	}},
+	"pauses": {"Pauses", func(r exportRecord, f csvFormat) string {
+		return strconv.Itoa(r.Pauses)
+	}},
+	"pause_time": {"Pause Time", func(r exportRecord, f csvFormat) string {
+		return f.duration(r.PauseTime)
+	}},
+	"work_time": {"Effective Work Time", func(r exportRecord, f csvFormat) string {
+		return f.duration(r.WorkTime)
+	}},
+	"rounded_work_time": {"Rounded Work Time", func(r exportRecord, f csvFormat) string {
+		return f.duration(r.RoundedWorkTime)
+	}},
+	"notes": {"Notes", func(r exportRecord, f csvFormat) string {
+		return optionalString(r.Notes)
+	}},
+	"tags": {"Tags", func(r exportRecord, f csvFormat) string {
+		if len(r.Tags) == 0 {
+			return "-"
+		}
+		return strings.Join(r.Tags, ",")
+	}},
+	"billable": {"Billable", func(r exportRecord, f csvFormat) string {
+		if r.Billable {
+			return "yes"
+		}
+		return "no"
+	}},
+	"amount": {"Amount", func(r exportRecord, f csvFormat) string {
+		if r.Amount == nil {
+			return "-"
+		}
+		return strconv.FormatFloat(*r.Amount, 'f', 2, 64)
+	}},
+	"currency": {"Currency", func(r exportRecord, f csvFormat) string {
+		if r.Amount == nil {
+			return "-"
+		}
+		return r.Currency
+	}},
+}
+
+// exportColumnHeaders returns the headers of the given export columns
+func exportColumnHeaders(columns []string) []string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = exportColumns[column].header
+	}
+
+	return headers
+}
+
+// time formats an optional time, or returns "-" if there is none
+func (f csvFormat) time(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.In(f.location).Format(f.timeLayout)
+}
+
+// duration formats an optional duration, or returns "-" if there is none
+func (f csvFormat) duration(d *time.Duration) string {
+	if d == nil {
+		return "-"
+	}
+
+	switch f.durationFormat {
+	case durationFormatDecimal:
+		return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+	case durationFormatMinutes:
+		return strconv.FormatFloat(math.Round(d.Minutes()), 'f', 0, 64)
+	case durationFormatSeconds:
+		return strconv.FormatFloat(math.Round(d.Seconds()), 'f', 0, 64)
+	default:
+		return formatDuration(*d)
+	}
+}
+
+// optionalString returns the value of an optional string, or "-" if there is none
+func optionalString(value *string) string {
+	if value == nil {
+		return "-"
+	}
+
+	return *value
+}
+
+// addExportProfileFlag adds the flag to select an export profile of the CSV export to the given cobra command
+func addExportProfileFlag(cmd *cobra.Command, profileVar *string) {
+	cmd.Flags().StringVar(profileVar, "profile", "", "Export profile from export_profiles of the configuration")
+}
+
+// getExportProfile returns the export profile of the given name from the configuration. An empty name returns the
+// profile of the default export.
+func getExportProfile(name string) (config.ExportProfile, error) {
+	if name == "" {
+		return config.ExportProfile{}, nil
+	}
+
+	// The configuration keys are case-insensitive
+	profile, ok := conf.ExportProfiles[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(conf.ExportProfiles))
+		for profileName := range conf.ExportProfiles {
+			names = append(names, profileName)
+		}
+		slices.Sort(names)
+
+		if len(names) == 0 {
+			return profile, fmt.Errorf("export profile %q not found, no profiles are configured in export_profiles", name)
+		}
+		return profile, fmt.Errorf("export profile %q not found, must be one of: %s", name, strings.Join(names, ", "))
+	}
+
+	return profile, nil
+}
+
+// newExportRecords creates the export records of the given time entries. The amount is based on the rounded work
+// time. If project is given, it is used as the project of all records.
+func newExportRecords(entries []repository.TimeEntryWithPauses, rates *service.BillingRates, rounding *service.RoundingRules, project string) []exportRecord {
+	rounded := rounding.RoundEntries(entries)
+
+	records := make([]exportRecord, 0, len(entries))
+	for _, entry := range entries {
+		record := exportRecord{
+			ID:        entry.ID,
+			ProjectID: entry.ProjectID,
+			StartTime: entry.StartTime,
+			EndTime:   entry.EndTime,
+			Project:   project,
+			Category:  entry.Category,
+			Pauses:    entry.PauseCount,
+			Tags:      entry.Tags,
+			Billable:  entry.Billable,
+		}
+
+		if record.Project == "" {
+			record.Project = entry.Project.Name
+		}
+
+		// The stored duration is the effective work time, the pauses are part of the total duration
+		if entry.Duration != nil {
+			duration := *entry.Duration + entry.PauseTime
+			record.Duration = &duration
+			record.WorkTime = entry.Duration
+		}
+
+		if entry.PauseTime > 0 {
+			pauseTime := entry.PauseTime
+			record.PauseTime = &pauseTime
+		}
+
+		billedEntry := entry.TimeEntry
+		if roundedDuration, ok := rounded[entry.ID]; ok {
+			record.RoundedWorkTime = &roundedDuration
+			billedEntry.Duration = &roundedDuration
+		}
+
+		if entry.Notes != nil && *entry.Notes != "" {
+			record.Notes = entry.Notes
+		}
+
+		if amount, currency, ok := rates.Amount(billedEntry); ok {
+			record.Amount = &amount
+			record.Currency = currency
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// exportTimesToCSV exports the given time entries to a CSV file with the specified filename.
+// The rounded work time is exported next to the exact one and the amount is based on the rounded work time.
+// The export profile selects the columns, delimiter and formats, its empty fields keep those of the default export.
+func exportTimesToCSV(entries []repository.TimeEntryWithPauses, rates *service.BillingRates, rounding *service.RoundingRules, filename string, project string, profile config.ExportProfile) (string, error) {
+	if filename == "" {
+		timestamp := time.Now().Format("20060102150405")
+		filename = fmt.Sprintf("%s_times.csv", timestamp)
+	}
+
+	columns := defaultExportColumns
+	if len(profile.Columns) > 0 {
+		columns = profile.Columns
+	}
+	for _, column := range columns {
+		if _, ok := exportColumns[column]; !ok {
+			return filename, fmt.Errorf("invalid export column %q", column)
+		}
+	}
+
+	format := csvFormat{
+		location:       time.Local,
+		timeLayout:     "2006-01-02 15:04:05",
+		durationFormat: profile.DurationFormat,
+	}
+	if profile.TimeZone != "" {
+		location, err := time.LoadLocation(profile.TimeZone)
+		if err != nil {
+			return filename, fmt.Errorf("invalid time zone %q: %w", profile.TimeZone, err)
+		}
+		format.location = location
+	}
+	if profile.TimeLayout != "" {
+		format.timeLayout = profile.TimeLayout
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return filename, err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if profile.Delimiter != "" {
+		writer.Comma = []rune(profile.Delimiter)[0]
+	}
+
+	if err := writer.Write(exportColumnHeaders(columns)); err != nil {
+		return filename, err
+	}
+
+	for _, record := range newExportRecords(entries, rates, rounding, project) {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = exportColumns[column].value(record, format)
+		}
+
+		if err := writer.Write(row); err != nil {
+			return filename, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return filename, err
+	}
+
+	return filename, file.Close()
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/ics"
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// exportTimesToICS exports the given time entries to an iCalendar file with an event per time entry. The UIDs of the
+// events are derived from the entry IDs, so importing the file again updates the events instead of duplicating them.
+// Pauses are listed in the description and a running entry ends at the time of the export.
+func exportTimesToICS(ctx context.Context, entries []repository.TimeEntryWithPauses, filename string) (string, error) {
+	if filename == "" {
+		timestamp := time.Now().Format("20060102150405")
+		filename = fmt.Sprintf("%s_times.ics", timestamp)
+	}
+
+	now := time.Now()
+	events := make([]ics.Event, 0, len(entries))
+	for _, entry := range entries {
+		var description []string
+		if entry.Category != nil {
+			description = append(description, "Category: "+*entry.Category)
+		}
+		if len(entry.Tags) > 0 {
+			description = append(description, "Tags: "+strings.Join(entry.Tags, ", "))
+		}
+		if entry.Notes != nil && *entry.Notes != "" {
+			description = append(description, "Notes: "+*entry.Notes)
+		}
+
+		pauses, err := completedPauses(ctx, entry)
+		if err != nil {
+			return filename, err
+		}
+		for _, pause := range pauses {
+			description = append(description, fmt.Sprintf("Pause: %s - %s", pause.PauseStart.Local().Format("15:04"), pause.PauseEnd.Local().Format("15:04")))
+		}
+
+		end := now
+		if entry.EndTime != nil && entry.Duration != nil {
+			end = *entry.EndTime
+			description = append(description, "Work time: "+formatDuration(*entry.Duration))
+		} else {
+			description = append(description, "In progress")
+		}
+
+		events = append(events, ics.Event{
+			UID:         fmt.Sprintf("hora-entry-%d@hora", entry.ID),
+			Summary:     entry.Project.Name,
+			Description: strings.Join(description, "\n"),
+			Start:       entry.StartTime,
+			End:         end,
+		})
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return filename, err
+	}
+	defer file.Close()
+
+	return filename, ics.Write(file, events, now)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// timeclockLayout is the date and time layout of the timeclock format
+const timeclockLayout = "2006-01-02 15:04:05"
+
+// exportTimesToTimeclock exports the given completed time entries to a file in the timeclock format of hledger and
+// ledger. The project and category form the account (e.g. client:dev) and the notes the description. Each pause
+// closes the clock and reopens it afterwards, so the totals of ledger match the effective work time.
+func exportTimesToTimeclock(ctx context.Context, entries []repository.TimeEntryWithPauses, filename string) (string, error) {
+	if filename == "" {
+		timestamp := time.Now().Format("20060102150405")
+		filename = fmt.Sprintf("%s_times.timeclock", timestamp)
+	}
+
+	// The clock has to be checked in and out in chronological order
+	sorted := make([]repository.TimeEntryWithPauses, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return filename, err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, entry := range sorted {
+		if entry.EndTime == nil {
+			// Running entries cannot be checked out yet
+			continue
+		}
+
+		account := timeclockName(entry.Project.Name)
+		if entry.Category != nil {
+			account += ":" + timeclockName(*entry.Category)
+		}
+
+		if entry.Notes != nil && *entry.Notes != "" {
+			// The description is separated from the account by two spaces
+			account += "  " + strings.Join(strings.Fields(*entry.Notes), " ")
+		}
+
+		pauses, err := completedPauses(ctx, entry)
+		if err != nil {
+			return filename, err
+		}
+
+		start := entry.StartTime
+		for _, pause := range pauses {
+			fmt.Fprintf(writer, "i %s %s\n", start.Local().Format(timeclockLayout), account)
+			fmt.Fprintf(writer, "o %s\n", pause.PauseStart.Local().Format(timeclockLayout))
+			start = *pause.PauseEnd
+		}
+		fmt.Fprintf(writer, "i %s %s\n", start.Local().Format(timeclockLayout), account)
+		fmt.Fprintf(writer, "o %s\n", entry.EndTime.Local().Format(timeclockLayout))
+	}
+
+	return filename, writer.Flush()
+}
+
+// timeclockName returns a name which can be used as part of an account, colons would nest the account and two
+// spaces would end it
+func timeclockName(name string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(name), " "), ":", "-")
+}
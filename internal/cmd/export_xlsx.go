@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/nitschmann/hora/internal/service"
+	"github.com/nitschmann/hora/internal/xlsx"
+)
+
+// xlsxSummaryHeader is the header of the summary sheet of the XLSX export
+var xlsxSummaryHeader = []string{
+	"Project",
+	"Entries",
+	"Duration",
+	"Pause Time",
+	"Effective Work Time",
+	"Rounded Work Time",
+	"Amount",
+	"Currency",
+}
+
+// xlsxSummaryColumns are the columns of the project sheets, as index in exportHeader, which the columns of the summary
+// sheet following the project sum up. The start times are counted instead.
+var xlsxSummaryColumns = []int{0, 4, 6, 7, 8, 12}
+
+// exportTimesToXLSX exports the given time entries to an Excel workbook with a sheet per project and a summary sheet.
+// The project sheets have the columns of the CSV export with times as date and time cells and durations as time
+// values, so they can be summed up. The summary sheet sums up the project sheets with formulas.
+func exportTimesToXLSX(entries []repository.TimeEntryWithPauses, rates *service.BillingRates, rounding *service.RoundingRules, filename string) (string, error) {
+	if filename == "" {
+		timestamp := time.Now().Format("20060102150405")
+		filename = fmt.Sprintf("%s_times.xlsx", timestamp)
+	}
+
+	byProject := make(map[string][]exportRecord)
+	for _, record := range newExportRecords(entries, rates, rounding, "") {
+		byProject[record.Project] = append(byProject[record.Project], record)
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	summary := xlsx.Sheet{Name: "Summary", Header: xlsxSummaryHeader}
+	sheets := []xlsx.Sheet{summary}
+	taken := []string{summary.Name}
+
+	var totals exportTotals
+	for _, project := range projects {
+		records := byProject[project]
+		sheet := xlsx.Sheet{
+			Name:   xlsx.SheetName(project, taken),
+			Header: exportHeader,
+			Rows:   make([][]xlsx.Cell, 0, len(records)),
+		}
+		taken = append(taken, sheet.Name)
+
+		var projectTotals exportTotals
+		for _, record := range records {
+			sheet.Rows = append(sheet.Rows, xlsxRecordCells(record))
+			projectTotals.add(record)
+		}
+		totals.merge(projectTotals)
+
+		sheets = append(sheets, sheet)
+		sheets[0].Rows = append(sheets[0].Rows, xlsxSummaryCells(project, projectTotals, func(column int) string {
+			function := "SUM"
+			if column == 0 {
+				function = "COUNTA"
+			}
+			// The rows of the project sheet start below the header
+			return fmt.Sprintf("%s(%s!%s:%s)", function, xlsx.SheetRef(sheet.Name), xlsx.CellRef(column, 1), xlsx.CellRef(column, len(records)))
+		}))
+	}
+
+	if len(projects) > 0 {
+		sheets[0].Rows = append(sheets[0].Rows, xlsxSummaryCells("Total", totals, func(column int) string {
+			// The total sums up the rows of the projects above
+			summaryColumn := slices.Index(xlsxSummaryColumns, column) + 1
+			return fmt.Sprintf("SUM(%s:%s)", xlsx.CellRef(summaryColumn, 1), xlsx.CellRef(summaryColumn, len(projects)))
+		}))
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return filename, err
+	}
+	defer file.Close()
+
+	if err := xlsx.Write(file, sheets); err != nil {
+		return filename, err
+	}
+
+	return filename, file.Close()
+}
+
+// exportTotals sums up export records
+type exportTotals struct {
+	Entries         int
+	Duration        time.Duration
+	PauseTime       time.Duration
+	WorkTime        time.Duration
+	RoundedWorkTime time.Duration
+	Amount          float64
+	Currencies      []string
+}
+
+// add adds a record to the totals
+func (t *exportTotals) add(record exportRecord) {
+	t.Entries++
+	addOptionalDuration(&t.Duration, record.Duration)
+	addOptionalDuration(&t.PauseTime, record.PauseTime)
+	addOptionalDuration(&t.WorkTime, record.WorkTime)
+	addOptionalDuration(&t.RoundedWorkTime, record.RoundedWorkTime)
+
+	if record.Amount != nil {
+		t.Amount += *record.Amount
+		t.addCurrency(record.Currency)
+	}
+}
+
+// merge adds other totals to the totals
+func (t *exportTotals) merge(other exportTotals) {
+	t.Entries += other.Entries
+	t.Duration += other.Duration
+	t.PauseTime += other.PauseTime
+	t.WorkTime += other.WorkTime
+	t.RoundedWorkTime += other.RoundedWorkTime
+	t.Amount += other.Amount
+	for _, currency := range other.Currencies {
+		t.addCurrency(currency)
+	}
+}
+
+// addCurrency records a currency of the summed up amounts
+func (t *exportTotals) addCurrency(currency string) {
+	if !slices.Contains(t.Currencies, currency) {
+		t.Currencies = append(t.Currencies, currency)
+	}
+}
+
+// addOptionalDuration adds an optional duration to a sum
+func addOptionalDuration(sum *time.Duration, duration *time.Duration) {
+	if duration != nil {
+		*sum += *duration
+	}
+}
+
+// xlsxRecordCells returns the cells of an export record in the order of exportHeader
+func xlsxRecordCells(record exportRecord) []xlsx.Cell {
+	cells := []xlsx.Cell{
+		{Value: record.StartTime.Local()},
+		{},
+		{Value: record.Project},
+		{},
+		xlsxDurationCell(record.Duration),
+		{Value: record.Pauses},
+		xlsxDurationCell(record.PauseTime),
+		xlsxDurationCell(record.WorkTime),
+		xlsxDurationCell(record.RoundedWorkTime),
+		{},
+		{},
+		{Value: "no"},
+		{},
+		{},
+	}
+
+	if record.EndTime != nil {
+		cells[1].Value = record.EndTime.Local()
+	}
+	if record.Category != nil {
+		cells[3].Value = *record.Category
+	}
+	if record.Notes != nil {
+		cells[9].Value = *record.Notes
+	}
+	if len(record.Tags) > 0 {
+		cells[10].Value = strings.Join(record.Tags, ",")
+	}
+	if record.Billable {
+		cells[11].Value = "yes"
+	}
+	if record.Amount != nil {
+		cells[12].Value = *record.Amount
+		cells[13].Value = record.Currency
+	}
+
+	return cells
+}
+
+// xlsxDurationCell returns a cell with an optional duration
+func xlsxDurationCell(duration *time.Duration) xlsx.Cell {
+	if duration == nil {
+		return xlsx.Cell{}
+	}
+
+	return xlsx.Cell{Value: *duration}
+}
+
+// xlsxSummaryCells returns the cells of a row of the summary sheet. The values are the formulas returned by formula
+// for the columns of exportHeader in xlsxSummaryColumns, the totals are their results until the workbook is
+// recalculated. Amounts of different currencies are not summed up.
+func xlsxSummaryCells(label string, totals exportTotals, formula func(column int) string) []xlsx.Cell {
+	cells := []xlsx.Cell{
+		{Value: label},
+		{Value: totals.Entries, Formula: formula(xlsxSummaryColumns[0])},
+		{Value: totals.Duration, Formula: formula(xlsxSummaryColumns[1])},
+		{Value: totals.PauseTime, Formula: formula(xlsxSummaryColumns[2])},
+		{Value: totals.WorkTime, Formula: formula(xlsxSummaryColumns[3])},
+		{Value: totals.RoundedWorkTime, Formula: formula(xlsxSummaryColumns[4])},
+		{},
+		{},
+	}
+
+	if len(totals.Currencies) <= 1 {
+		cells[6] = xlsx.Cell{Value: totals.Amount, Formula: formula(xlsxSummaryColumns[5])}
+	}
+	if len(totals.Currencies) > 0 {
+		cells[7].Value = strings.Join(totals.Currencies, ", ")
+	}
+
+	return cells
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/importer"
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/output"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+// importProjectSummary is the structured output of the imported entries of a project
+type importProjectSummary struct {
+	Project    string        `json:"project"`
+	New        bool          `json:"new"`
+	Entries    int           `json:"entries"`
+	Duplicates int           `json:"duplicates"`
+	WorkTime   time.Duration `json:"work_time"`
+}
+
+// importSummary is the structured output of an import
+type importSummary struct {
+	DryRun     bool                   `json:"dry_run"`
+	Entries    int                    `json:"entries"`
+	Duplicates int                    `json:"duplicates"`
+	WorkTime   time.Duration          `json:"work_time"`
+	Projects   []importProjectSummary `json:"projects"`
+}
+
+func NewImportCmd() *cobra.Command {
+	var (
+		format  string
+		project string
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import time entries from Toggl, Clockify or Timewarrior",
+		Long: fmt.Sprintf(`Import the time entries of another time tracker. Supported formats (%s):
+  toggl-csv     Detailed report CSV export of Toggl Track
+  clockify-csv  Detailed report CSV export of Clockify
+  timewarrior   JSON output of 'timew export', the first tag of an interval is the project
+
+Projects are created as needed, tasks become categories, descriptions and annotations notes. Task and tag names are
+converted to valid hora names, e.g. "Code review" becomes "Code-review". Entries of the same project and start time as
+an existing entry are skipped, so a file can be imported again safely. Running timers are not imported.
+Use 'hora import csv' to import the CSV export of hora itself.`, strings.Join(importer.Formats, ", ")),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open file: %w", err)
+			}
+			defer file.Close()
+
+			parsed, err := importer.Parse(format, file)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", args[0], err)
+			}
+
+			entries, err := newImportEntries(parsed, project)
+			if err != nil {
+				return err
+			}
+
+			return importEntries(cmd, entries, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", fmt.Sprintf("Format of the file (%s)", strings.Join(importer.Formats, ", ")))
+	_ = cmd.MarkFlagRequired("format")
+	addImportFlags(cmd, &project, &dryRun)
+
+	cmd.AddCommand(NewImportCSVCmd())
+
+	return cmd
+}
+
+// addImportFlags adds the flags which all import commands share to the given cobra command
+func addImportFlags(cmd *cobra.Command, projectVar *string, dryRunVar *bool) {
+	cmd.Flags().StringVar(projectVar, "project", "", "Project of the entries which have none in the file")
+	cmd.Flags().BoolVar(dryRunVar, "dry-run", false, "Show what would be imported without changing any data")
+}
+
+// newImportEntries converts the parsed entries of an import, entries without project are assigned to the given one
+func newImportEntries(parsed []importer.Entry, project string) ([]service.ImportEntry, error) {
+	entries := make([]service.ImportEntry, len(parsed))
+	for i, entry := range parsed {
+		projectName := entry.Project
+		if projectName == "" {
+			if project == "" {
+				return nil, fmt.Errorf("entry starting at %s has no project, use --project to assign one", formatTimeInLocal(entry.Start))
+			}
+			projectName = project
+		}
+
+		attrs := service.EntryAttributes{Tags: entry.Tags, NonBillable: !entry.Billable}
+		if entry.Category != "" {
+			category := entry.Category
+			attrs.Category = &category
+		}
+		if entry.Notes != "" {
+			notes := entry.Notes
+			attrs.Notes = &notes
+		}
+
+		entries[i] = service.ImportEntry{
+			ProjectName: projectName,
+			StartTime:   entry.Start,
+			EndTime:     entry.End,
+			Attributes:  attrs,
+		}
+
+		// Only the total pause time is known, it becomes a single pause in the middle of the entry so the effective
+		// work time is preserved
+		if entry.PauseTime > 0 {
+			pauseStart := entry.Start.Add((entry.End.Sub(entry.Start) - entry.PauseTime) / 2)
+			pauseEnd := pauseStart.Add(entry.PauseTime)
+			entries[i].Pauses = []model.Pause{{PauseStart: pauseStart, PauseEnd: &pauseEnd}}
+		}
+	}
+
+	return entries, nil
+}
+
+// importEntries imports the entries and renders a summary per project
+func importEntries(cmd *cobra.Command, entries []service.ImportEntry, dryRun bool) error {
+	result, err := timeService.ImportEntries(cmd.Context(), entries, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to import time entries: %w", mapCmdError(err))
+	}
+
+	summary := importSummary{
+		DryRun:     dryRun,
+		Entries:    result.Entries,
+		Duplicates: result.Duplicates,
+		WorkTime:   result.WorkTime,
+		Projects:   make([]importProjectSummary, len(result.Projects)),
+	}
+
+	table := &output.Table{Header: []string{"Project", "New", "Entries", "Duplicates", "Work Time"}}
+	for i, project := range result.Projects {
+		summary.Projects[i] = importProjectSummary{
+			Project:    project.Name,
+			New:        project.New,
+			Entries:    project.Entries,
+			Duplicates: project.Duplicates,
+			WorkTime:   project.WorkTime,
+		}
+
+		isNew := "no"
+		if project.New {
+			isNew = "yes"
+		}
+		table.Append([]string{project.Name, isNew, fmt.Sprintf("%d", project.Entries), fmt.Sprintf("%d", project.Duplicates), formatDuration(project.WorkTime)})
+	}
+	table.Footer = []string{"Total", "", fmt.Sprintf("%d", result.Entries), fmt.Sprintf("%d", result.Duplicates), formatDuration(result.WorkTime)}
+
+	if outputFormat == output.FormatTable {
+		if dryRun {
+			fmt.Printf("Dry run: would import %d time entries and skip %d duplicates\n", result.Entries, result.Duplicates)
+		} else {
+			fmt.Printf("Imported %d time entries and skipped %d duplicates\n", result.Entries, result.Duplicates)
+		}
+
+		if len(result.Projects) == 0 {
+			return nil
+		}
+	}
+
+	return renderOutput(cmd, summary, table)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/importer"
+)
+
+func NewImportCSVCmd() *cobra.Command {
+	var (
+		project string
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "csv FILE",
+		Short: "Import time entries from a CSV export of hora",
+		Long: `Import the time entries of a CSV file written by 'hora export' or 'hora project export-times'.
+The project column is optional, entries of files without it are assigned to the project given with --project.
+The export only contains the total pause time of an entry, it becomes a single pause in the middle of the entry so the effective work time is preserved.
+Entries of the same project and start time as an existing entry are skipped, so a file can be imported again safely.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open file: %w", err)
+			}
+			defer file.Close()
+
+			parsed, err := importer.ParseCSV(file)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", args[0], err)
+			}
+
+			entries, err := newImportEntries(parsed, project)
+			if err != nil {
+				return err
+			}
+
+			return importEntries(cmd, entries, dryRun)
+		},
+	}
+
+	addImportFlags(cmd, &project, &dryRun)
+
+	return cmd
+}
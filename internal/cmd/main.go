@@ -3,15 +3,19 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nitschmann/hora/internal/config"
+	"github.com/nitschmann/hora/internal/output"
 )
 
 var (
 	conf               *config.Config
 	usedConfigFilepath string
+	// outputFormat is the value of the global --output flag
+	outputFormat string
 	// Version is the current version of the cli application
 	Version string
 
@@ -39,6 +43,10 @@ func NewRootCmd() *cobra.Command {
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
+			if err = output.Validate(outputFormat); err != nil {
+				return err
+			}
+
 			configFileFlagValue, err := cmd.Flags().GetString("config")
 			if err != nil {
 				return fmt.Errorf("failed to get config flag: %w", err)
@@ -72,17 +80,33 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to configuration file")
+	// Without a shorthand, as -o is the file of the export commands
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", output.FormatTable, fmt.Sprintf("Output format of lists and reports (%s)", strings.Join(output.Formats, ", ")))
 
+	rootCmd.AddCommand(NewAbsenceCmd())
+	rootCmd.AddCommand(NewAddCmd())
+	rootCmd.AddCommand(NewBackupCmd())
+	rootCmd.AddCommand(NewBalanceCmd())
 	rootCmd.AddCommand(NewCategoriesCmd())
+	rootCmd.AddCommand(NewComplianceCmd())
 	rootCmd.AddCommand(NewContinueCmd())
 	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewDeleteAllCmd())
+	rootCmd.AddCommand(NewEditCmd())
+	rootCmd.AddCommand(NewEntryCmd())
 	rootCmd.AddCommand(NewExportCmd())
+	rootCmd.AddCommand(NewImportCmd())
+	rootCmd.AddCommand(NewNoteCmd())
 	rootCmd.AddCommand(NewStartCmd())
 	rootCmd.AddCommand(NewStopCmd())
 	rootCmd.AddCommand(NewPauseCmd())
+	rootCmd.AddCommand(NewReportCmd())
+	rootCmd.AddCommand(NewRestoreCmd())
 	rootCmd.AddCommand(NewStatusCmd())
+	rootCmd.AddCommand(NewSwitchCmd())
+	rootCmd.AddCommand(NewTagsCmd())
 	rootCmd.AddCommand(NewTimesCmd())
+	rootCmd.AddCommand(NewTimesheetCmd())
 	rootCmd.AddCommand(NewLogsCmd())
 	rootCmd.AddCommand(NewUICommand())
 	rootCmd.AddCommand(NewVersionCmd())
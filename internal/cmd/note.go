@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewNoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note [text]",
+		Short: "Add a note to the current time tracking session",
+		Long:  `Append a note to the currently active time tracking session. Notes of an entry are kept line by line in the order they were added.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			entry, err := timeService.AppendNote(ctx, strings.Join(args, " "))
+			if err != nil {
+				return fmt.Errorf("failed to add note: %w", err)
+			}
+
+			fmt.Printf("Added note to time entry %d for project: %s\n", entry.ID, entry.Project.Name)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -7,13 +7,22 @@ import (
 )
 
 func NewPauseCmd() *cobra.Command {
+	var at string
+
 	cmd := &cobra.Command{
 		Use:   "pause",
 		Short: "Pause the currently active time tracking session",
 		Long:  `Pause the currently active time tracking session. You can resume it later with the 'continue' command.`,
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			err := timeService.PauseTracking(ctx)
+
+			pauseStart, err := parseAtFlag(at)
+			if err != nil {
+				return err
+			}
+
+			err = timeService.PauseTracking(ctx, pauseStart)
 			if err != nil {
 				return fmt.Errorf("failed to pause tracking: %w", err)
 			}
@@ -23,5 +32,10 @@ func NewPauseCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&at, "at", "", "Pause at this time instead of now (YYYY-MM-DD HH:MM format, or HH:MM for today)")
+
+	cmd.AddCommand(NewPauseListCmd())
+	cmd.AddCommand(NewPauseRemoveCmd())
+
 	return cmd
 }
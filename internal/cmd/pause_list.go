@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewPauseListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list [TIME_ENTRY_ID]",
+		Aliases: []string{"ls"},
+		Short:   "List the pauses of a time entry",
+		Long:    `List all pauses of a time entry, showing their IDs, start time, end time and duration.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid time entry ID: %s", args[0])
+			}
+
+			pauses, err := timeService.GetPausesForEntry(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get pauses: %w", mapCmdError(err))
+			}
+
+			if len(pauses) == 0 {
+				fmt.Printf("No pauses found for time entry %d.\n", id)
+				return nil
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			table.Header("ID", "Start Time", "End Time", "Duration")
+
+			for _, pause := range pauses {
+				endStr := "Active"
+				durationStr := "In progress"
+				if pause.PauseEnd != nil {
+					endStr = formatTimeInLocal(*pause.PauseEnd)
+				}
+				if pause.Duration != nil {
+					durationStr = timeService.FormatDuration(*pause.Duration)
+				}
+
+				table.Append([]string{
+					strconv.Itoa(pause.ID),
+					formatTimeInLocal(pause.PauseStart),
+					endStr,
+					durationStr,
+				})
+			}
+
+			table.Render()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func NewPauseRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "remove [PAUSE_ID]",
+		Aliases: []string{"rm"},
+		Short:   "Remove a single pause",
+		Long:    `Remove a single pause from its time entry. The pause time is counted as work time again and the work duration of the time entry is recomputed. This action cannot be undone.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid pause ID: %s", args[0])
+			}
+
+			if !force {
+				fmt.Printf("This will delete pause %d. Are you sure? (y/N): ", id)
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					fmt.Println("Operation cancelled.")
+					return nil
+				}
+			}
+
+			ctx := cmd.Context()
+			entry, err := timeService.RemovePause(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to remove pause: %w", mapCmdError(err))
+			}
+
+			fmt.Printf("Pause %d has been removed from time entry %d.\n", id, entry.ID)
+			if entry.Duration != nil {
+				fmt.Printf("Duration: %s\n", timeService.FormatDuration(*entry.Duration))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
@@ -12,8 +12,11 @@ func NewProjectCmd() *cobra.Command {
 		Long:    `Manage projects in your time tracking system.`,
 	}
 
+	cmd.AddCommand(NewProjectBudgetCmd())
 	cmd.AddCommand(NewProjectListCmd())
+	cmd.AddCommand(NewProjectRateCmd())
 	cmd.AddCommand(NewProjectRemoveCmd())
+	cmd.AddCommand(NewProjectRoundingCmd())
 	cmd.AddCommand(NewProjectTimesCmd())
 	cmd.AddCommand(NewProjectExportTimesCmd())
 	cmd.AddCommand(NewProjectTotalCmd())
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewProjectBudgetCmd() *cobra.Command {
+	var (
+		period string
+		remove bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "budget [PROJECT_ID_OR_NAME] [BUDGET]",
+		Short: "Show or set the time budget of a project",
+		Long: `Show or set the time budget of a project, e.g. for fixed-price projects. The budget is given as duration like 40h or 7h30m.
+With --period month the budget is renewed every calendar month, with --period total it covers all time tracked for the project.
+Without a budget, the used and remaining budget of the project is shown.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			projectIDOrName := args[0]
+
+			if remove {
+				if len(args) > 1 {
+					return fmt.Errorf("a budget cannot be given together with --remove")
+				}
+
+				project, err := timeService.RemoveProjectBudget(ctx, projectIDOrName)
+				if err != nil {
+					return fmt.Errorf("failed to remove budget: %w", mapCmdError(err))
+				}
+				fmt.Printf("Removed budget for project: %s\n", project.Name)
+				return nil
+			}
+
+			if len(args) == 1 {
+				status, err := timeService.GetBudgetStatus(ctx, projectIDOrName, time.Now())
+				if err != nil {
+					return fmt.Errorf("failed to get budget: %w", mapCmdError(err))
+				}
+
+				if status == nil {
+					fmt.Printf("No budget set for project: %s\n", projectIDOrName)
+					return nil
+				}
+
+				fmt.Printf("Project: %s\n", status.Project.Name)
+				printBudgetStatus(status)
+				return nil
+			}
+
+			budget, err := time.ParseDuration(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid budget '%s', use a duration like 40h or 7h30m", args[1])
+			}
+
+			project, err := timeService.SetProjectBudget(ctx, projectIDOrName, budget, period)
+			if err != nil {
+				return fmt.Errorf("failed to set budget: %w", mapCmdError(err))
+			}
+			fmt.Printf("Set budget for project %s to %s (%s)\n", project.Name, timeService.FormatDuration(budget), formatBudgetPeriod(period))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&period, "period", model.BudgetPeriodTotal, "Budget period (month or total)")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the budget")
+
+	return cmd
+}
+
+// printBudgetStatus prints the used and remaining budget of a project
+func printBudgetStatus(status *service.BudgetStatus) {
+	fmt.Printf("Budget: %s (%s)\n", timeService.FormatDuration(status.Budget), formatBudgetPeriod(status.Period))
+	fmt.Printf("Used: %s (%.0f%%)\n", timeService.FormatDuration(status.Used), status.UsedRatio()*100)
+	fmt.Printf("Remaining: %s\n", formatBudgetRemaining(status))
+}
+
+// printBudgetWarning prints a warning if the project of the given name is already over its budget
+func printBudgetWarning(ctx context.Context, projectName string) {
+	if warning := budgetWarning(ctx, projectName); warning != "" {
+		fmt.Println(warning)
+	}
+}
+
+// budgetWarning returns the warning that the project of the given name is already over its budget, or an empty
+// string if it is not
+func budgetWarning(ctx context.Context, projectName string) string {
+	status, err := timeService.GetBudgetStatus(ctx, projectName, time.Now())
+	if err != nil || status == nil || !status.Exceeded() {
+		return ""
+	}
+
+	return fmt.Sprintf("Warning: project %s is over its budget (%s of %s used, %s)",
+		status.Project.Name,
+		timeService.FormatDuration(status.Used),
+		timeService.FormatDuration(status.Budget),
+		formatBudgetPeriod(status.Period),
+	)
+}
+
+// formatBudgetRemaining formats the remaining budget, or by how much it has been exceeded
+func formatBudgetRemaining(status *service.BudgetStatus) string {
+	remaining := status.Remaining()
+	if remaining < 0 {
+		return fmt.Sprintf("exceeded by %s", timeService.FormatDuration(-remaining))
+	}
+
+	return timeService.FormatDuration(remaining)
+}
+
+// formatBudgetPeriod returns a human readable description of a budget period
+func formatBudgetPeriod(period string) string {
+	if period == model.BudgetPeriodMonth {
+		return "per month"
+	}
+
+	return "in total"
+}
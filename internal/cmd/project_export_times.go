@@ -3,43 +3,58 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 )
 
 func NewProjectExportTimesCmd() *cobra.Command {
 	var (
-		since  string
-		sort   string
-		output string
-		limit  int
+		since     string
+		until     string
+		rangeExpr string
+		sort      string
+		file      string
+		limit     int
+		profile   string
+		raw       bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "export-times [project]",
 		Short: "Export project time entries to CSV",
-		Long:  "Export time entries for a specific project to a CSV file",
-		Args:  cobra.ExactArgs(1),
+		Long: `Export time entries for a specific project to a CSV file.
+With --profile the columns, delimiter, time zone, time layout and duration format of a profile in export_profiles of the configuration are used.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			projectName := args[0]
 
-			var sinceTime *time.Time
-			if since != "" {
-				parsed, err := time.Parse("2006-01-02", since)
-				if err != nil {
-					return fmt.Errorf("invalid date format: %w", err)
-				}
-				sinceTime = &parsed
+			csvProfile, err := getExportProfile(profile)
+			if err != nil {
+				return err
 			}
 
-			entries, err := timeService.GetEntriesForProjectWithPauses(ctx, projectName, limit, sort, sinceTime)
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			entries, err := timeService.GetEntriesForProjectWithPauses(ctx, projectName, limit, sort, sinceTime, untilTime)
 			if err != nil {
 				return fmt.Errorf("failed to get project time entries: %w", err)
 			}
 
-			filename, err := exportTimesToCSV(entries, output, projectName)
+			rates, err := timeService.GetBillingRates(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get billing rates: %w", err)
+			}
+
+			rounding, err := getRoundingRules(ctx, raw)
+			if err != nil {
+				return err
+			}
+
+			filename, err := exportTimesToCSV(entries, rates, rounding, file, projectName, csvProfile)
 			if err != nil {
 				return fmt.Errorf("failed to export CSV: %w", err)
 			}
@@ -50,8 +65,11 @@ func NewProjectExportTimesCmd() *cobra.Command {
 	}
 
 	addListCommandCommonFlags(cmd, &limit, &since, &sort)
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	addRawFlag(cmd, &raw)
 
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path (default: TIMESTAMP_PROJECT_times.csv)")
+	cmd.Flags().StringVarP(&file, "file", "o", "", "Output file path (default: TIMESTAMP_PROJECT_times.csv)")
+	addExportProfileFlag(cmd, &profile)
 
 	return cmd
 }
@@ -3,8 +3,9 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/output"
 )
 
 func NewProjectListCmd() *cobra.Command {
@@ -20,13 +21,12 @@ func NewProjectListCmd() *cobra.Command {
 				return fmt.Errorf("failed to get projects: %w", err)
 			}
 
-			if len(projects) == 0 {
+			if len(projects) == 0 && outputFormat == output.FormatTable {
 				fmt.Println("No projects found.")
 				return nil
 			}
 
-			table := tablewriter.NewTable(cmd.OutOrStdout())
-			table.Header("ID", "Name", "Created", "Last Tracked")
+			table := &output.Table{Header: []string{"ID", "Name", "Created", "Last Tracked"}}
 
 			for _, project := range projects {
 				createdStr := formatTimeInLocalShort(project.CreatedAt)
@@ -46,9 +46,7 @@ func NewProjectListCmd() *cobra.Command {
 				})
 			}
 
-			table.Render()
-
-			return nil
+			return renderOutput(cmd, projects, table)
 		},
 	}
 
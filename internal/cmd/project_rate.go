@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewProjectRateCmd() *cobra.Command {
+	var (
+		currency string
+		category string
+		remove   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rate [PROJECT_ID_OR_NAME] [HOURLY_RATE]",
+		Short: "Show or set the hourly rate of a project",
+		Long: `Show or set the hourly rate of a project which is used to calculate the revenue of billable time entries.
+Use --category to set a rate which overrides the project rate for entries of a category, and --remove to remove a rate.
+Without a rate, the current rates of the project are shown.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			projectIDOrName := args[0]
+
+			if category != "" {
+				if err := validateCategory(category); err != nil {
+					return fmt.Errorf("invalid category: %w", err)
+				}
+			}
+
+			currency, err := normalizeCurrency(currency)
+			if err != nil {
+				return err
+			}
+
+			if remove {
+				if len(args) > 1 {
+					return fmt.Errorf("a rate cannot be given together with --remove")
+				}
+
+				if category != "" {
+					project, err := timeService.RemoveCategoryRate(ctx, projectIDOrName, category)
+					if err != nil {
+						return fmt.Errorf("failed to remove category rate: %w", mapCmdError(err))
+					}
+					fmt.Printf("Removed hourly rate of category '%s' for project: %s\n", category, project.Name)
+					return nil
+				}
+
+				project, err := timeService.RemoveProjectRate(ctx, projectIDOrName)
+				if err != nil {
+					return fmt.Errorf("failed to remove hourly rate: %w", mapCmdError(err))
+				}
+				fmt.Printf("Removed hourly rate for project: %s\n", project.Name)
+				return nil
+			}
+
+			if len(args) == 1 {
+				return showProjectRates(cmd, projectIDOrName)
+			}
+
+			rate, err := strconv.ParseFloat(args[1], 64)
+			if err != nil {
+				return fmt.Errorf("invalid hourly rate: %s", args[1])
+			}
+
+			if category != "" {
+				if currency != "" {
+					return fmt.Errorf("--currency cannot be used with --category, category rates use the currency of the project")
+				}
+
+				project, err := timeService.SetCategoryRate(ctx, projectIDOrName, category, rate)
+				if err != nil {
+					return fmt.Errorf("failed to set category rate: %w", mapCmdError(err))
+				}
+				fmt.Printf("Set hourly rate of category '%s' for project %s to %.2f\n", category, project.Name, rate)
+				return nil
+			}
+
+			project, err := timeService.SetProjectRate(ctx, projectIDOrName, rate, currency)
+			if err != nil {
+				return fmt.Errorf("failed to set hourly rate: %w", mapCmdError(err))
+			}
+			fmt.Printf("Set hourly rate for project %s to %.2f %s\n", project.Name, *project.HourlyRate, *project.Currency)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&currency, "currency", "", "Currency of the hourly rate as three letter code (default: current currency of the project or EUR)")
+	cmd.Flags().StringVar(&category, "category", "", "Set or remove the rate for entries of this category only")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the hourly rate")
+
+	return cmd
+}
+
+// showProjectRates prints the project rate and category overrides of a project
+func showProjectRates(cmd *cobra.Command, projectIDOrName string) error {
+	ctx := cmd.Context()
+
+	project, err := timeService.GetProjectByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", mapCmdError(err))
+	}
+
+	rates, err := timeService.GetBillingRates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get billing rates: %w", err)
+	}
+
+	categoryRates := rates.CategoryRates(project.ID)
+	if project.HourlyRate == nil && len(categoryRates) == 0 {
+		fmt.Printf("No hourly rate set for project: %s\n", project.Name)
+		return nil
+	}
+
+	currency := "-"
+	if project.Currency != nil {
+		currency = *project.Currency
+	}
+
+	table := tablewriter.NewTable(cmd.OutOrStdout())
+	table.Header("Category", "Hourly Rate", "Currency")
+
+	projectRate := "-"
+	if project.HourlyRate != nil {
+		projectRate = fmt.Sprintf("%.2f", *project.HourlyRate)
+	}
+	table.Append([]string{"(project)", projectRate, currency})
+
+	categories := make([]string, 0, len(categoryRates))
+	for category := range categoryRates {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		table.Append([]string{category, fmt.Sprintf("%.2f", categoryRates[category]), currency})
+	}
+
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+func NewProjectRoundingCmd() *cobra.Command {
+	var (
+		increment int
+		scope     string
+		remove    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rounding [PROJECT_ID_OR_NAME] [MODE]",
+		Short: "Show or set the rounding of a project",
+		Long: `Show or set the rounding rule which overrides the configured rounding for a project. The mode is one of up, down, nearest or none.
+The work time is rounded to multiples of --increment minutes, either per entry, per day or over a whole report or export (--scope).
+The stored times are never changed. Use --remove to use the configured rounding again.
+Without a mode, the rounding which applies to the project is shown.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			projectIDOrName := args[0]
+
+			if remove {
+				if len(args) > 1 {
+					return fmt.Errorf("a mode cannot be given together with --remove")
+				}
+
+				project, err := timeService.RemoveProjectRounding(ctx, projectIDOrName)
+				if err != nil {
+					return fmt.Errorf("failed to remove rounding: %w", mapCmdError(err))
+				}
+				fmt.Printf("Removed rounding for project: %s\n", project.Name)
+				return nil
+			}
+
+			if len(args) == 1 {
+				project, err := timeService.GetProjectByIDOrName(ctx, projectIDOrName)
+				if err != nil {
+					return fmt.Errorf("failed to get project: %w", mapCmdError(err))
+				}
+
+				rounding := configuredRounding()
+				source := "configuration"
+				if override := project.RoundingOverride(); override != nil {
+					rounding = *override
+					source = "project"
+				}
+
+				fmt.Printf("Project: %s\n", project.Name)
+				fmt.Printf("Rounding: %s (%s)\n", formatRounding(rounding), source)
+				return nil
+			}
+
+			rounding := model.Rounding{
+				Mode:      args[1],
+				Increment: time.Duration(increment) * time.Minute,
+				Scope:     scope,
+			}
+
+			project, err := timeService.SetProjectRounding(ctx, projectIDOrName, rounding)
+			if err != nil {
+				return fmt.Errorf("failed to set rounding: %w", mapCmdError(err))
+			}
+			fmt.Printf("Set rounding for project %s to %s\n", project.Name, formatRounding(rounding))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&increment, "increment", conf.Rounding.Increment, "Rounding increment in minutes, e.g. 6 or 15")
+	cmd.Flags().StringVar(&scope, "scope", conf.Rounding.Scope, "Rounding scope (entry, day or report)")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the rounding of the project")
+
+	return cmd
+}
+
+// formatRounding formats a rounding rule, e.g. "up to 15 minutes per entry"
+func formatRounding(rounding model.Rounding) string {
+	if !rounding.Enabled() {
+		return "none"
+	}
+
+	scope := "per entry"
+	switch rounding.Scope {
+	case model.RoundingScopeDay:
+		scope = "per day"
+	case model.RoundingScopeReport:
+		scope = "per report"
+	}
+
+	return fmt.Sprintf("%s to %d minutes %s", rounding.Mode, int(rounding.Increment.Minutes()), scope)
+}
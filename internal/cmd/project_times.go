@@ -2,17 +2,21 @@ package cmd
 
 import (
 	"fmt"
-	"time"
+	"strconv"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/output"
 )
 
 func NewProjectTimesCmd() *cobra.Command {
 	var (
-		limit int
-		sort  string
-		since string
+		limit     int
+		sort      string
+		since     string
+		until     string
+		rangeExpr string
+		raw       bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,13 +33,9 @@ func NewProjectTimesCmd() *cobra.Command {
 				return fmt.Errorf("sort order must be 'asc' or 'desc', got: %s", sort)
 			}
 
-			var sinceTime *time.Time
-			if since != "" {
-				parsedTime, err := time.Parse("2006-01-02", since)
-				if err != nil {
-					return fmt.Errorf("invalid date format for --since flag. Use YYYY-MM-DD format: %w", err)
-				}
-				sinceTime = &parsedTime
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
 			}
 
 			project, err := timeService.GetProjectByIDOrName(ctx, projectIDOrName)
@@ -43,18 +43,28 @@ func NewProjectTimesCmd() *cobra.Command {
 				return fmt.Errorf("failed to get project: %w", mapCmdError(err))
 			}
 
-			entries, err := timeService.GetEntriesForProjectWithPauses(ctx, projectIDOrName, limit, sort, sinceTime)
+			entries, err := timeService.GetEntriesForProjectWithPauses(ctx, projectIDOrName, limit, sort, sinceTime, untilTime)
 			if err != nil {
 				return fmt.Errorf("failed to get time entries: %w", mapCmdError(err))
 			}
 
-			if len(entries) == 0 {
+			if len(entries) == 0 && outputFormat == output.FormatTable {
 				fmt.Printf("No time entries found for project '%s'.\n", project.Name)
 				return nil
 			}
 
-			table := tablewriter.NewTable(cmd.OutOrStdout())
-			table.Header("Start Time", "End Time", "Category", "Duration", "Pauses", "Pause Time", "Effective Work Time")
+			rounding, err := getRoundingRules(ctx, raw)
+			if err != nil {
+				return err
+			}
+			rounded := rounding.RoundEntries(entries)
+
+			table := &output.Table{}
+			header := []string{"ID", "Start Time", "End Time", "Category", "Duration", "Pauses", "Pause Time", "Effective Work Time"}
+			if rounding.Enabled() {
+				header = append(header, "Rounded Work Time")
+			}
+			table.Header = header
 
 			// Add rows
 			for _, entry := range entries {
@@ -98,7 +108,8 @@ func NewProjectTimesCmd() *cobra.Command {
 					categoryStr = "-"
 				}
 
-				table.Append([]string{
+				row := []string{
+					strconv.Itoa(entry.ID),
 					startStr,
 					endStr,
 					categoryStr,
@@ -106,16 +117,21 @@ func NewProjectTimesCmd() *cobra.Command {
 					pauseCountStr,
 					pauseTimeStr,
 					effectiveWorkTimeStr,
-				})
-			}
+				}
+				if rounding.Enabled() {
+					row = append(row, formatRoundedDuration(rounded, entry.ID))
+				}
 
-			table.Render()
+				table.Append(row)
+			}
 
-			return nil
+			return renderOutput(cmd, entries, table)
 		},
 	}
 
 	addListCommandCommonFlags(cmd, &limit, &since, &sort)
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	addRawFlag(cmd, &raw)
 
 	return cmd
 }
@@ -4,30 +4,44 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/output"
 )
 
+// projectTotal is the structured output of the project total command. The budget of the project is part of the
+// project, BudgetUsed refers to its current budget period.
+type projectTotal struct {
+	Project     model.Project  `json:"project"`
+	TotalTime   time.Duration  `json:"total_time"`
+	RoundedTime *time.Duration `json:"rounded_time,omitempty"`
+	Since       *time.Time     `json:"since,omitempty"`
+	Until       *time.Time     `json:"until,omitempty"`
+	BudgetUsed  *time.Duration `json:"budget_used,omitempty"`
+}
+
 func NewProjectTotalCmd() *cobra.Command {
-	var since string
+	var (
+		since     string
+		until     string
+		rangeExpr string
+		raw       bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "total [PROJECT_ID_OR_NAME]",
 		Short: "Show total tracked time for a project",
-		Long:  `Show the total tracked time for a specific project, including all time entries and accounting for pauses. You can specify either the project ID (numeric) or name.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the total tracked time for a specific project, including all time entries and accounting for pauses. You can specify either the project ID (numeric) or name.
+If rounding is configured for the project, the rounded total of the completed entries is shown as well.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			projectIDOrName := args[0]
 
-			// Parse since date if provided
-			var sinceTime *time.Time
-			if since != "" {
-				parsedTime, err := time.Parse("2006-01-02", since)
-				if err != nil {
-					return fmt.Errorf("invalid date format for --since flag. Use YYYY-MM-DD format: %w", err)
-				}
-				sinceTime = &parsedTime
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
 			}
 
 			// First check if the project exists
@@ -37,31 +51,83 @@ func NewProjectTotalCmd() *cobra.Command {
 			}
 
 			// Get total time for the project
-			totalTime, err := timeService.GetTotalTimeForProject(ctx, projectIDOrName, sinceTime)
+			totalTime, err := timeService.GetTotalTimeForProject(ctx, projectIDOrName, sinceTime, untilTime)
 			if err != nil {
 				return fmt.Errorf("failed to get total time: %w", mapCmdError(err))
 			}
 
-			table := tablewriter.NewTable(cmd.OutOrStdout())
-			table.Header("Project", "Total Time", "Since")
+			rounding, err := getRoundingRules(ctx, raw)
+			if err != nil {
+				return err
+			}
+
+			var roundedTotal *time.Duration
+			if rounding.For(project.ID).Enabled() {
+				entries, err := timeService.GetEntriesForProjectWithPauses(ctx, projectIDOrName, -1, "asc", sinceTime, untilTime)
+				if err != nil {
+					return fmt.Errorf("failed to get time entries: %w", mapCmdError(err))
+				}
+
+				total := rounding.Total(entries)
+				roundedTotal = &total
+			}
+
+			budgetStatus, err := timeService.GetBudgetStatus(ctx, projectIDOrName, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to get budget: %w", mapCmdError(err))
+			}
+
+			total := projectTotal{
+				Project:     *project,
+				TotalTime:   totalTime,
+				RoundedTime: roundedTotal,
+				Since:       sinceTime,
+				Until:       untilTime,
+			}
+
 			formatedSince := ""
 			if sinceTime != nil {
 				formatedSince = formatDateInLocal(*sinceTime)
 			}
 
-			table.Append([]string{
+			header := []string{"Project", "Total Time"}
+			row := []string{
 				project.Name,
 				timeService.FormatDuration(totalTime),
-				formatedSince,
-			})
+			}
 
-			table.Render()
+			if roundedTotal != nil {
+				header = append(header, "Rounded Time")
+				row = append(row, timeService.FormatDuration(*roundedTotal))
+			}
+
+			header = append(header, "Since")
+			row = append(row, formatedSince)
+
+			// The upper bound is exclusive, so the last included day is shown
+			if untilTime != nil {
+				header = append(header, "Until")
+				row = append(row, formatDateInLocal(untilTime.Add(-time.Nanosecond)))
+			}
+
+			// The budget columns refer to the current budget period, independent of the date filters
+			if budgetStatus != nil {
+				header = append(header, "Budget", "Budget Used", "Budget Remaining")
+				row = append(row,
+					fmt.Sprintf("%s (%s)", timeService.FormatDuration(budgetStatus.Budget), formatBudgetPeriod(budgetStatus.Period)),
+					fmt.Sprintf("%s (%.0f%%)", timeService.FormatDuration(budgetStatus.Used), budgetStatus.UsedRatio()*100),
+					formatBudgetRemaining(budgetStatus),
+				)
+				total.BudgetUsed = &budgetStatus.Used
+			}
 
-			return nil
+			return renderOutput(cmd, total, &output.Table{Header: header, Rows: [][]string{row}})
 		},
 	}
 
-	cmd.Flags().StringVar(&since, "since", "", "Only include time since this date (YYYY-MM-DD format)")
+	cmd.Flags().StringVar(&since, "since", "", "Only include time since this date or expression (e.g. 2025-10-16, yesterday, last week, 2h ago)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	addRawFlag(cmd, &raw)
 
 	return cmd
 }
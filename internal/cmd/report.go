@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/nitschmann/hora/internal/ui"
+)
+
+func NewReportCmd() *cobra.Command {
+	var (
+		htmlFile  string
+		project   string
+		category  string
+		tags      []string
+		allTags   bool
+		since     string
+		until     string
+		rangeExpr string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show reports about tracked time",
+		Long: `Show aggregated reports about the tracked time.
+With --html a self-contained HTML report of the entries within the given period and filters is written to the given file.
+It has the charts of the web UI (daily activity, time by project and time by category) and a table of the entries,
+and needs no network connection to be viewed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if htmlFile == "" {
+				return cmd.Help()
+			}
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			var filters []string
+			var categoryPtr *string
+			if category != "" {
+				if err := validateCategory(category); err != nil {
+					return err
+				}
+				categoryPtr = &category
+				filters = append(filters, "Category: "+category)
+			}
+
+			tagList, err := normalizeTags(tags)
+			if err != nil {
+				return err
+			}
+			if len(tagList) > 0 {
+				separator := " or "
+				if allTags {
+					separator = " and "
+				}
+				filters = append(filters, "Tags: "+strings.Join(tagList, separator))
+			}
+
+			entries, err := timeService.GetAllEntriesWithPausesFiltered(ctx, -1, "asc", repository.TimeEntryFilter{
+				Since:        sinceTime,
+				Until:        untilTime,
+				Category:     categoryPtr,
+				Tags:         tagList,
+				MatchAllTags: allTags,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get time entries: %w", err)
+			}
+
+			if project != "" {
+				proj, err := timeService.GetProjectByIDOrName(ctx, project)
+				if err != nil {
+					return fmt.Errorf("failed to get project: %w", mapCmdError(err))
+				}
+				entries = slices.DeleteFunc(entries, func(entry repository.TimeEntryWithPauses) bool {
+					return entry.ProjectID != proj.ID
+				})
+				filters = slices.Insert(filters, 0, "Project: "+proj.Name)
+			}
+
+			file, err := os.Create(htmlFile)
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+			defer file.Close()
+
+			err = ui.WriteReport(file, ui.Report{
+				Entries:     entries,
+				Since:       sinceTime,
+				Until:       untilTime,
+				Filters:     filters,
+				GeneratedAt: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Wrote report of %d time entries to %s\n", len(entries), htmlFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&htmlFile, "html", "", "Write an HTML report to this file")
+	cmd.Flags().StringVar(&project, "project", "", "Only report entries of this project (ID or name)")
+	cmd.Flags().StringVar(&category, "category", "", "Only report entries of this category")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only report entries with this tag, can be given multiple times (entries with any of the tags)")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Only report entries which have all of the given tags")
+	cmd.Flags().StringVar(&since, "since", "", "Only report entries since this date or expression (e.g. 2025-10-16, yesterday, last week)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+
+	cmd.AddCommand(NewReportDayCmd())
+	cmd.AddCommand(NewReportMonthCmd())
+	cmd.AddCommand(NewReportRevenueCmd())
+	cmd.AddCommand(NewReportWeekCmd())
+
+	return cmd
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/daterange"
+	"github.com/nitschmann/hora/internal/output"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+// periodReportRow is the structured output of a row of a period report
+type periodReportRow struct {
+	// Groups holds the value per dimension, subtotals only carry the value of the first dimension
+	Groups    map[string]string `json:"groups,omitempty"`
+	Subtotal  bool              `json:"subtotal,omitempty"`
+	Entries   int               `json:"entries"`
+	WorkTime  time.Duration     `json:"work_time"`
+	PauseTime time.Duration     `json:"pause_time"`
+	Share     float64           `json:"share"`
+}
+
+// periodReportAbsence is the structured output of a day with absences of a period report
+type periodReportAbsence struct {
+	Date     string        `json:"date"`
+	Types    []string      `json:"types"`
+	Credited time.Duration `json:"credited"`
+}
+
+// periodReport is the structured output of a period report. End is the last day of the period, the total holds the
+// tracked time and Credited the time credited by absences.
+type periodReport struct {
+	Start    string                `json:"start"`
+	End      string                `json:"end"`
+	GroupBy  []string              `json:"group_by"`
+	Rows     []periodReportRow     `json:"rows"`
+	Absences []periodReportAbsence `json:"absences"`
+	Credited time.Duration         `json:"credited"`
+	Total    periodReportRow       `json:"total"`
+}
+
+func NewReportDayCmd() *cobra.Command {
+	return newReportPeriodCmd("day", "today", []string{service.ReportGroupProject, service.ReportGroupCategory})
+}
+
+func NewReportWeekCmd() *cobra.Command {
+	return newReportPeriodCmd("week", "this week", []string{service.ReportGroupDay, service.ReportGroupProject})
+}
+
+func NewReportMonthCmd() *cobra.Command {
+	return newReportPeriodCmd("month", "this month", []string{service.ReportGroupProject, service.ReportGroupCategory})
+}
+
+// newReportPeriodCmd creates the report command for a day, week or month. The optional argument is a date or date
+// expression which selects the period containing it.
+func newReportPeriodCmd(period string, defaultExpr string, defaultGroups []string) *cobra.Command {
+	var groupBy []string
+
+	cmd := &cobra.Command{
+		Use:   period + " [DATE]",
+		Short: fmt.Sprintf("Show the tracked time of a %s grouped by day, project or category", period),
+		Long: fmt.Sprintf(`Show the effective work time, pause time and share of the total of a %[1]s, grouped by day, project and/or category.
+The %[1]s containing the given date or expression (e.g. 2025-10-16, yesterday, last %[1]s, 2025-W42) is reported, %[2]s by default.
+With more than one group a subtotal follows each value of the first group. Entries are accounted to the day they started.
+Absences like vacation or public holidays credit the expected work time of their days according to 'work_schedule' and are part of the total.`, period, defaultExpr),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			expr := defaultExpr
+			if len(args) > 0 {
+				expr = args[0]
+			}
+
+			r, err := daterange.Parse(expr, time.Now(), conf.WeekStartDay())
+			if err != nil {
+				return err
+			}
+
+			switch period {
+			case "day":
+				r = daterange.Day(r.Start)
+			case "week":
+				r = daterange.Week(r.Start, conf.WeekStartDay())
+			default:
+				r = daterange.Month(r.Start)
+			}
+
+			report, err := timeService.GetPeriodReport(ctx, conf.WorkSchedule, r.Start, r.End, groupBy)
+			if err != nil {
+				return fmt.Errorf("failed to get report: %w", mapCmdError(err))
+			}
+
+			lastDay := formatDateInLocal(report.End.AddDate(0, 0, -1))
+			if outputFormat == output.FormatTable {
+				if len(report.Rows) == 0 && len(report.Absences) == 0 {
+					fmt.Printf("No time entries or absences found from %s to %s.\n", formatDateInLocal(report.Start), lastDay)
+					return nil
+				}
+
+				fmt.Printf("Report from %s to %s\n", formatDateInLocal(report.Start), lastDay)
+			}
+
+			return renderOutput(cmd, newPeriodReport(report), reportTable(report))
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&groupBy, "group-by", defaultGroups, fmt.Sprintf("Dimensions to group by, in order (%s)", strings.Join(service.ReportGroups, ", ")))
+
+	return cmd
+}
+
+// reportTable returns the formatted rows of a period report with the total as footer
+func reportTable(report *service.PeriodReport) *output.Table {
+	table := &output.Table{}
+	for _, group := range report.GroupBy {
+		table.Header = append(table.Header, strings.ToUpper(group[:1])+group[1:])
+	}
+	table.Header = append(table.Header, "Entries", "Work Time", "Pause Time", "Share")
+
+	for _, row := range report.Rows {
+		values := make([]string, len(row.Values))
+		for i, value := range row.Values {
+			switch {
+			case row.Subtotal && i == 1:
+				values[i] = "Subtotal"
+			case row.Subtotal && i > 1:
+				values[i] = ""
+			case value == "":
+				values[i] = "-"
+			default:
+				values[i] = value
+			}
+		}
+
+		table.Append(append(values, formatReportNumbers(row.Entries, row.WorkTime, row.PauseTime, row.Share)...))
+	}
+
+	// The time credited by absences follows the tracked time and is part of the total
+	if len(report.Absences) > 0 {
+		values := make([]string, len(report.GroupBy))
+		types := strings.Join(absenceTypes(report.Absences), ", ")
+		if len(values) > 1 {
+			values[0], values[1] = "Absences", types
+		} else {
+			values[0] = fmt.Sprintf("Absences (%s)", types)
+		}
+
+		table.Append(append(values, "-", formatDuration(report.Credited), "-", fmt.Sprintf("%.1f%%", report.CreditedShare()*100)))
+	}
+
+	share := 0.0
+	if report.Total() > 0 {
+		share = 1
+	}
+
+	table.Footer = make([]string, len(report.GroupBy))
+	table.Footer[0] = "Total"
+	table.Footer = append(table.Footer, formatReportNumbers(report.Entries, report.Total(), report.PauseTime, share)...)
+
+	return table
+}
+
+// absenceTypes returns the distinct types of the absences of the given days
+func absenceTypes(credits []service.AbsenceCredit) []string {
+	var types []string
+	for _, credit := range credits {
+		for _, absence := range credit.Absences {
+			if !slices.Contains(types, absence.Type) {
+				types = append(types, absence.Type)
+			}
+		}
+	}
+
+	return types
+}
+
+// formatReportNumbers formats the aggregated values of a report row
+func formatReportNumbers(entries int, workTime time.Duration, pauseTime time.Duration, share float64) []string {
+	return []string{
+		fmt.Sprintf("%d", entries),
+		formatDuration(workTime),
+		formatDuration(pauseTime),
+		fmt.Sprintf("%.1f%%", share*100),
+	}
+}
+
+// newPeriodReport converts a period report into its structured output
+func newPeriodReport(report *service.PeriodReport) periodReport {
+	result := periodReport{
+		Start:    formatDateInLocal(report.Start),
+		End:      formatDateInLocal(report.End.AddDate(0, 0, -1)),
+		GroupBy:  report.GroupBy,
+		Rows:     make([]periodReportRow, 0, len(report.Rows)),
+		Absences: make([]periodReportAbsence, 0, len(report.Absences)),
+		Credited: report.Credited,
+		Total: periodReportRow{
+			Entries:   report.Entries,
+			WorkTime:  report.WorkTime,
+			PauseTime: report.PauseTime,
+		},
+	}
+	if report.WorkTime > 0 {
+		result.Total.Share = 1 - report.CreditedShare()
+	}
+
+	for _, credit := range report.Absences {
+		result.Absences = append(result.Absences, periodReportAbsence{
+			Date:     formatDateInLocal(credit.Date),
+			Types:    absenceTypes([]service.AbsenceCredit{credit}),
+			Credited: credit.Credited,
+		})
+	}
+
+	for _, row := range report.Rows {
+		groups := make(map[string]string, len(row.Values))
+		for i, value := range row.Values {
+			if row.Subtotal && i > 0 {
+				continue
+			}
+			groups[report.GroupBy[i]] = value
+		}
+
+		result.Rows = append(result.Rows, periodReportRow{
+			Groups:    groups,
+			Subtotal:  row.Subtotal,
+			Entries:   row.Entries,
+			WorkTime:  row.WorkTime,
+			PauseTime: row.PauseTime,
+			Share:     row.Share,
+		})
+	}
+
+	return result
+}
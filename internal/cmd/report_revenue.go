@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewReportRevenueCmd() *cobra.Command {
+	var (
+		since     string
+		until     string
+		rangeExpr string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revenue",
+		Short: "Show billable hours and revenue per project",
+		Long: `Show the billable hours and the revenue per project, calculated from the hourly rates of the projects and their categories.
+Only completed, billable time entries which started within the given period are included. Billable time of projects without a rate is reported as unrated.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
+			}
+
+			report, err := timeService.GetRevenueReport(ctx, sinceTime, untilTime)
+			if err != nil {
+				return fmt.Errorf("failed to get revenue report: %w", err)
+			}
+
+			if len(report) == 0 {
+				fmt.Println("No billable time entries found.")
+				return nil
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			table.Header("Project", "Billable Hours", "Unrated Hours", "Amount", "Currency")
+
+			totals := make(map[string]float64)
+			var unrated time.Duration
+			for _, revenue := range report {
+				table.Append([]string{
+					revenue.Project.Name,
+					fmt.Sprintf("%.2f", revenue.BillableDuration.Hours()),
+					fmt.Sprintf("%.2f", revenue.UnratedDuration.Hours()),
+					fmt.Sprintf("%.2f", revenue.Amount),
+					revenue.Currency,
+				})
+
+				if revenue.UnratedDuration < revenue.BillableDuration {
+					totals[revenue.Currency] += revenue.Amount
+				}
+				unrated += revenue.UnratedDuration
+			}
+
+			table.Render()
+
+			currencies := make([]string, 0, len(totals))
+			for currency := range totals {
+				currencies = append(currencies, currency)
+			}
+			sort.Strings(currencies)
+
+			for _, currency := range currencies {
+				fmt.Printf("Total: %.2f %s\n", totals[currency], currency)
+			}
+
+			if unrated > 0 {
+				fmt.Printf("%.2f billable hours have no hourly rate. Use 'hora project rate' to set one.\n", unrated.Hours())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only include entries since this date or expression (e.g. 2025-10-16, yesterday, last month)")
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+
+	return cmd
+}
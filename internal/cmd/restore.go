@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/service"
+)
+
+func NewRestoreCmd() *cobra.Command {
+	var (
+		merge   bool
+		replace bool
+		force   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore a JSON backup",
+		Long: `Restore a backup written by 'hora backup'. The backup is validated first and restored within a single transaction, so nothing changes if it fails.
+With --replace all existing data is deleted and the backup is restored with its original IDs.
+With --merge the backup is added to the existing data: projects are matched by name, time entries of the same project and start time and absences which exist already are skipped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			mode := service.RestoreModeMerge
+			if replace {
+				mode = service.RestoreModeReplace
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read backup: %w", err)
+			}
+
+			var backup service.Backup
+			if err := json.Unmarshal(data, &backup); err != nil {
+				return fmt.Errorf("failed to parse backup: %w", err)
+			}
+
+			if err := backup.Validate(); err != nil {
+				return fmt.Errorf("invalid backup: %w", err)
+			}
+
+			if replace && !force {
+				fmt.Print("This will replace ALL time tracking data with the backup. Are you sure? (y/N): ")
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					fmt.Println("Operation cancelled.")
+					return nil
+				}
+			}
+
+			result, err := timeService.RestoreBackup(ctx, &backup, mode)
+			if err != nil {
+				return fmt.Errorf("failed to restore backup: %w", mapCmdError(err))
+			}
+
+			fmt.Printf("Restored %d projects, %d time entries, %d pauses and %d absences from %s\n",
+				result.Projects, result.TimeEntries, result.Pauses, result.Absences, args[0])
+			if result.SkippedTimeEntries > 0 || result.SkippedAbsences > 0 {
+				fmt.Printf("Skipped %d time entries and %d absences which exist already\n", result.SkippedTimeEntries, result.SkippedAbsences)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&merge, "merge", false, "Add the backup to the existing data")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Delete all existing data and restore the backup")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt of --replace")
+	cmd.MarkFlagsOneRequired("merge", "replace")
+	cmd.MarkFlagsMutuallyExclusive("merge", "replace")
+
+	return cmd
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nitschmann/hora/internal/backgroundtracker"
+	"github.com/nitschmann/hora/internal/service"
 )
 
 func NewStartCmd() *cobra.Command {
@@ -14,6 +16,10 @@ func NewStartCmd() *cobra.Command {
 		force                 bool
 		skipBackgroundTracker bool
 		category              string
+		note                  string
+		tags                  []string
+		billable              bool
+		at                    string
 	)
 
 	cmd := &cobra.Command{
@@ -27,6 +33,21 @@ func NewStartCmd() *cobra.Command {
 			project := args[0]
 			useBackgroundTracker := conf.UseBackgroundTracker && !skipBackgroundTracker
 
+			startTime, err := parseAtFlag(at)
+			if err != nil {
+				return err
+			}
+
+			tagList, err := normalizeTags(tags)
+			if err != nil {
+				return err
+			}
+
+			var notePtr *string
+			if note = strings.TrimSpace(note); note != "" {
+				notePtr = &note
+			}
+
 			// Validate category if provided
 			var categoryPtr *string
 			if category != "" {
@@ -53,6 +74,9 @@ func NewStartCmd() *cobra.Command {
 					}
 				}
 
+				// The daemon's output is not shown, so the parent warns about the budget before it closes the database
+				warning := budgetWarning(ctx, project)
+
 				// Close parent database connection before forking
 				if dbConn != nil {
 					_ = dbConn.Close()
@@ -65,25 +89,32 @@ func NewStartCmd() *cobra.Command {
 				// Parent exits here, daemon continues
 				if os.Getenv("IS_DAEMON") != "1" {
 					fmt.Printf("Started tracking time for project: %s\n", project)
+					if warning != "" {
+						fmt.Println(warning)
+					}
 					return nil
 				}
 
-				err := initDatabaseConnectionAndService()
+				err = initDatabaseConnectionAndService()
 				if err != nil {
 					return fmt.Errorf("failed to initialize database and service in daemon: %w", err)
 				}
 			}
 
 			// --- only daemon process reaches this point ---
-			err := timeService.StartTracking(ctx, project, force, categoryPtr)
+			attrs := service.EntryAttributes{Category: categoryPtr, Notes: notePtr, Tags: tagList, NonBillable: !billable}
+			err = timeService.StartTracking(ctx, project, force, attrs, startTime)
 			if err != nil {
 				return err
 			}
 
 			fmt.Printf("Started tracking time for project: %s\n", project)
 
+			// The parent process has already warned about the budget before it started the daemon
 			if useBackgroundTracker {
 				backgroundtracker.Start(conf, timeService)
+			} else {
+				printBudgetWarning(ctx, project)
 			}
 
 			return nil
@@ -93,6 +124,10 @@ func NewStartCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Stop any existing tracking session and start a new one")
 	cmd.Flags().BoolVar(&skipBackgroundTracker, "skip-background-tracker", false, "Skip starting the background tracker")
 	cmd.Flags().StringVar(&category, "category", "", "Category for this time entry (alphanumeric, underscore, hyphen only)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag for this time entry, can be given multiple times (alphanumeric, underscore, hyphen only)")
+	cmd.Flags().StringVar(&note, "note", "", "Note describing the work of this time entry")
+	cmd.Flags().BoolVar(&billable, "billable", true, "Whether this time entry is billable (use --billable=false for non-billable work)")
+	cmd.Flags().StringVar(&at, "at", "", "Start at this time instead of now (YYYY-MM-DD HH:MM format, or HH:MM for today)")
 
 	return cmd
 }
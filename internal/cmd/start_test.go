@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nitschmann/hora/internal/config"
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+// setupTestService points the commands to a new database in a temporary directory
+func setupTestService(t *testing.T) {
+	previousConf, previousService, previousConn := conf, timeService, dbConn
+	t.Cleanup(func() {
+		if dbConn != nil {
+			_ = dbConn.Close()
+		}
+		conf, timeService, dbConn = previousConf, previousService, previousConn
+	})
+
+	conf = &config.Config{DatabaseDir: t.TempDir(), UseBackgroundTracker: false}
+	require.NoError(t, initDatabaseConnectionAndService())
+}
+
+// captureStdout returns what fn writes to the standard output
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	fn()
+	require.NoError(t, writer.Close())
+
+	out, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestStartCmd_BudgetWarning(t *testing.T) {
+	setupTestService(t)
+	ctx := context.Background()
+
+	start := time.Now().Add(-3 * time.Hour)
+	_, err := timeService.AddEntry(ctx, "client", start, start.Add(2*time.Hour), service.EntryAttributes{}, nil)
+	require.NoError(t, err)
+	_, err = timeService.SetProjectBudget(ctx, "client", time.Hour, model.BudgetPeriodTotal)
+	require.NoError(t, err)
+
+	cmd := NewStartCmd()
+	cmd.SetArgs([]string{"client"})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = cmd.ExecuteContext(ctx)
+	})
+
+	require.NoError(t, runErr)
+	assert.Contains(t, out, "Started tracking time for project: client")
+	assert.Contains(t, out, "Warning: project client is over its budget (02:00:00 of 01:00:00 used, in total)")
+
+	active, err := timeService.GetActiveEntry(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, "client", active.Project.Name)
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/output"
 )
 
 func NewStatusCmd() *cobra.Command {
@@ -23,6 +26,18 @@ func NewStatusCmd() *cobra.Command {
 			currentDuration := time.Since(activeEntry.StartTime)
 			durationStr := timeService.FormatDuration(currentDuration)
 
+			if outputFormat != output.FormatTable {
+				categoryStr := "-"
+				if activeEntry.Category != nil {
+					categoryStr = *activeEntry.Category
+				}
+
+				return renderOutput(cmd, activeEntry, &output.Table{
+					Header: []string{"ID", "Project", "Category", "Started", "Duration"},
+					Rows:   [][]string{{strconv.Itoa(activeEntry.ID), activeEntry.Project.Name, categoryStr, formatTimeInLocal(activeEntry.StartTime), durationStr}},
+				})
+			}
+
 			fmt.Printf("Active session:\n\n")
 			fmt.Printf("Project: %s\n", activeEntry.Project.Name)
 			if activeEntry.Category != nil {
@@ -31,6 +46,15 @@ func NewStatusCmd() *cobra.Command {
 			fmt.Printf("Started: %s\n", formatTimeInLocal(activeEntry.StartTime))
 			fmt.Printf("Duration: %s\n", durationStr)
 
+			status, err := timeService.GetBudgetStatus(ctx, strconv.Itoa(activeEntry.ProjectID), time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to get budget: %w", err)
+			}
+			if status != nil {
+				fmt.Println()
+				printBudgetStatus(status)
+			}
+
 			return nil
 		},
 	}
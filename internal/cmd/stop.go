@@ -9,13 +9,24 @@ import (
 )
 
 func NewStopCmd() *cobra.Command {
+	var (
+		note string
+		at   string
+	)
+
 	cmd := &cobra.Command{
 		Use:   "stop",
 		Short: "Stop the current time tracking session",
 		Long:  `Stop the currently active time tracking session and display the duration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			entry, err := timeService.StopTracking(ctx)
+
+			endTime, err := parseAtFlag(at)
+			if err != nil {
+				return err
+			}
+
+			entry, err := timeService.StopTracking(ctx, endTime, note)
 			if err != nil {
 				fmt.Println("Failed to stop time tracking")
 				return err
@@ -37,5 +48,8 @@ func NewStopCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&note, "note", "", "Note to append to the time entry before stopping it")
+	cmd.Flags().StringVar(&at, "at", "", "Stop at this time instead of now (YYYY-MM-DD HH:MM format, or HH:MM for today)")
+
 	return cmd
 }
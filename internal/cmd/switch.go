@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewSwitchCmd() *cobra.Command {
+	var (
+		category string
+		at       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "switch [project]",
+		Short: "Switch tracking to another project",
+		Long:  `Stop the currently active time tracking session and start a new one for another project at the same instant. A running background tracker keeps running and picks up the new session.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			project := args[0]
+
+			switchTime, err := parseAtFlag(at)
+			if err != nil {
+				return err
+			}
+
+			// Validate category if provided
+			var categoryPtr *string
+			if category != "" {
+				if err := validateCategory(category); err != nil {
+					return fmt.Errorf("invalid category: %w", err)
+				}
+				categoryPtr = &category
+			}
+
+			stoppedEntry, err := timeService.SwitchTracking(ctx, project, categoryPtr, switchTime)
+			if err != nil {
+				return fmt.Errorf("failed to switch tracking: %w", err)
+			}
+
+			fmt.Printf("Stopped tracking time for project: %s\n", stoppedEntry.Project.Name)
+			fmt.Printf("Duration: %s\n", timeService.FormatDuration(*stoppedEntry.Duration))
+			fmt.Printf("Started tracking time for project: %s\n", project)
+			printBudgetWarning(ctx, project)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&category, "category", "", "Category for the new time entry (alphanumeric, underscore, hyphen only)")
+	cmd.Flags().StringVar(&at, "at", "", "Switch at this time instead of now (YYYY-MM-DD HH:MM format, or HH:MM for today)")
+
+	return cmd
+}
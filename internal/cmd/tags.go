@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewTagsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List all tags with their usage counts",
+		Long:  `Display all tags in alphabetical order together with the number of time entries they are assigned to.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			tags, err := timeService.GetTags(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get tags: %w", err)
+			}
+
+			if len(tags) == 0 {
+				fmt.Println("No tags found.")
+				return nil
+			}
+
+			table := tablewriter.NewTable(cmd.OutOrStdout())
+			table.Header("Tag", "Entries")
+
+			for _, tag := range tags {
+				table.Append([]string{
+					tag.Name,
+					strconv.Itoa(tag.UsageCount),
+				})
+			}
+
+			table.Render()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -2,20 +2,26 @@ package cmd
 
 import (
 	"fmt"
-	"time"
+	"strconv"
+	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
+	"github.com/nitschmann/hora/internal/output"
 	"github.com/nitschmann/hora/internal/repository"
 )
 
 func NewTimesCmd() *cobra.Command {
 	var (
-		category string
-		limit    int
-		since    string
-		sort     string
+		category  string
+		tags      []string
+		allTags   bool
+		limit     int
+		since     string
+		until     string
+		rangeExpr string
+		sort      string
+		raw       bool
 	)
 
 	cmd := &cobra.Command{
@@ -31,13 +37,9 @@ func NewTimesCmd() *cobra.Command {
 				return fmt.Errorf("sort order must be 'asc' or 'desc', got: %s", sort)
 			}
 
-			var sinceTime *time.Time
-			if since != "" {
-				parsedTime, err := time.Parse("2006-01-02", since)
-				if err != nil {
-					return fmt.Errorf("invalid date format for --since flag. Use YYYY-MM-DD format: %w", err)
-				}
-				sinceTime = &parsedTime
+			sinceTime, untilTime, err := parseDateFilters(since, until, rangeExpr)
+			if err != nil {
+				return err
 			}
 
 			// Validate category if provided
@@ -49,25 +51,40 @@ func NewTimesCmd() *cobra.Command {
 				categoryPtr = &category
 			}
 
-			// Get all time entries across all projects
-			var entries []repository.TimeEntryWithPauses
-			if categoryPtr != nil {
-				entries, err = timeService.GetAllEntriesWithPausesByCategory(ctx, limit, sort, sinceTime, categoryPtr)
-			} else {
-				entries, err = timeService.GetAllEntriesWithPauses(ctx, limit, sort, sinceTime)
+			tagList, err := normalizeTags(tags)
+			if err != nil {
+				return err
 			}
+
+			// Get all time entries across all projects
+			entries, err := timeService.GetAllEntriesWithPausesFiltered(ctx, limit, sort, repository.TimeEntryFilter{
+				Since:        sinceTime,
+				Until:        untilTime,
+				Category:     categoryPtr,
+				Tags:         tagList,
+				MatchAllTags: allTags,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to get time entries: %w", err)
 			}
 
-			if len(entries) == 0 {
+			if len(entries) == 0 && outputFormat == output.FormatTable {
 				fmt.Println("No time entries found.")
 				return nil
 			}
 
-			// Create table
-			table := tablewriter.NewTable(cmd.OutOrStdout())
-			table.Header("Start Time", "End Time", "Project", "Category", "Duration", "Pauses", "Pause Time", "Effective Work Time")
+			rounding, err := getRoundingRules(ctx, raw)
+			if err != nil {
+				return err
+			}
+			rounded := rounding.RoundEntries(entries)
+
+			table := &output.Table{}
+			header := []string{"ID", "Start Time", "End Time", "Project", "Category", "Tags", "Duration", "Pauses", "Pause Time", "Effective Work Time"}
+			if rounding.Enabled() {
+				header = append(header, "Rounded Work Time")
+			}
+			table.Header = append(header, "Notes")
 
 			// Add rows
 			for _, entry := range entries {
@@ -109,27 +126,48 @@ func NewTimesCmd() *cobra.Command {
 					categoryStr = "-"
 				}
 
-				table.Append([]string{
+				// Format tags
+				tagsStr := "-"
+				if len(entry.Tags) > 0 {
+					tagsStr = strings.Join(entry.Tags, ", ")
+				}
+
+				// Format notes
+				notesStr := "-"
+				if entry.Notes != nil && *entry.Notes != "" {
+					notesStr = *entry.Notes
+				}
+
+				row := []string{
+					strconv.Itoa(entry.ID),
 					startStr,
 					endStr,
 					entry.Project.Name,
 					categoryStr,
+					tagsStr,
 					durationStr,
 					pauseCountStr,
 					pauseTimeStr,
 					effectiveWorkTimeStr,
-				})
-			}
+				}
+				if rounding.Enabled() {
+					row = append(row, formatRoundedDuration(rounded, entry.ID))
+				}
 
-			table.Render()
+				table.Append(append(row, notesStr))
+			}
 
-			return nil
+			return renderOutput(cmd, entries, table)
 		},
 	}
 
 	addListCommandCommonFlags(cmd, &limit, &since, &sort)
+	addDateRangeFlags(cmd, &until, &rangeExpr)
+	addRawFlag(cmd, &raw)
 
 	cmd.Flags().StringVar(&category, "category", "", "Filter by category (avoid shell special characters like ! $ ` \\)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Filter by tag, can be given multiple times (entries with any of the tags)")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Only show entries which have all of the given tags")
 
 	return cmd
 }
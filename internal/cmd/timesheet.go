@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nitschmann/hora/internal/daterange"
+	"github.com/nitschmann/hora/internal/output"
+	"github.com/nitschmann/hora/internal/service"
+)
+
+// timesheetRow is the structured output of a row of a timesheet, Days holds the work time per day of the week
+type timesheetRow struct {
+	Project  string          `json:"project"`
+	Category string          `json:"category,omitempty"`
+	Days     []time.Duration `json:"days"`
+	Total    time.Duration   `json:"total"`
+}
+
+// timesheet is the structured output of a timesheet, Absences holds the time credited by absences per day of the week
+type timesheet struct {
+	Days      []string        `json:"days"`
+	Rows      []timesheetRow  `json:"rows"`
+	Absences  []time.Duration `json:"absences"`
+	DayTotals []time.Duration `json:"day_totals"`
+	Total     time.Duration   `json:"total"`
+}
+
+func NewTimesheetCmd() *cobra.Command {
+	var (
+		week       string
+		decimal    bool
+		byCategory bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timesheet",
+		Short: "Show the work time per project and weekday of a week",
+		Long: `Show a timesheet of a week with a row per project and a column per day, with totals per project and day.
+The week containing the given date or expression (e.g. 2025-W42, 2025-10-16, last week) is shown, the current week by default.
+Entries which span midnight are split across the days. Absences credit the expected work time of their days according to 'work_schedule'.
+Use --output csv for a spreadsheet friendly output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			r, err := daterange.Parse(week, time.Now(), conf.WeekStartDay())
+			if err != nil {
+				return fmt.Errorf("invalid --week value: %w", err)
+			}
+
+			sheet, err := timeService.GetTimesheet(ctx, conf.WorkSchedule, daterange.Week(r.Start, conf.WeekStartDay()).Start, byCategory)
+			if err != nil {
+				return fmt.Errorf("failed to get timesheet: %w", mapCmdError(err))
+			}
+
+			formatCell := func(d time.Duration) string {
+				if decimal {
+					return fmt.Sprintf("%.2f", d.Hours())
+				}
+				return formatHoursMinutes(d)
+			}
+
+			table := &output.Table{Header: []string{"Project"}}
+			if byCategory {
+				table.Header = append(table.Header, "Category")
+			}
+
+			dayLayout := "Mon 2006-01-02"
+			if outputFormat == output.FormatTable {
+				fmt.Printf("Week from %s to %s\n", formatDateInLocal(sheet.Days[0]), formatDateInLocal(sheet.Days[len(sheet.Days)-1]))
+
+				if len(sheet.Rows) == 0 && sheet.Credited == 0 {
+					fmt.Println("No time entries or absences found.")
+					return nil
+				}
+
+				// Empty cells are easier to scan in the table than zero durations
+				dayLayout = "Mon Jan 2"
+				formatHours := formatCell
+				formatCell = func(d time.Duration) string {
+					if d == 0 {
+						return "-"
+					}
+					return formatHours(d)
+				}
+			}
+
+			for _, day := range sheet.Days {
+				table.Header = append(table.Header, day.Format(dayLayout))
+			}
+			table.Header = append(table.Header, "Total")
+
+			for _, row := range sheet.Rows {
+				table.Append(timesheetRecord(row, byCategory, formatCell))
+			}
+			if sheet.Credited > 0 {
+				absences := service.TimesheetRow{Project: "Absences", Days: sheet.Absences, Total: sheet.Credited}
+				table.Append(timesheetRecord(absences, byCategory, formatCell))
+			}
+			table.Footer = timesheetTotalRecord(sheet, byCategory, formatCell)
+
+			return renderOutput(cmd, newTimesheet(sheet), table)
+		},
+	}
+
+	cmd.Flags().StringVar(&week, "week", "this week", "Week to show as ISO week, date or expression (e.g. 2025-W42, 2025-10-16, last week)")
+	cmd.Flags().BoolVar(&decimal, "decimal", false, "Show decimal hours (e.g. 7.50) instead of HH:MM")
+	cmd.Flags().BoolVar(&byCategory, "by-category", false, "Show a row per project and category")
+
+	return cmd
+}
+
+// timesheetRecord returns the formatted cells of a timesheet row
+func timesheetRecord(row service.TimesheetRow, byCategory bool, formatCell func(time.Duration) string) []string {
+	record := []string{row.Project}
+	if byCategory {
+		category := row.Category
+		if category == "" {
+			category = "-"
+		}
+		record = append(record, category)
+	}
+
+	for _, day := range row.Days {
+		record = append(record, formatCell(day))
+	}
+
+	return append(record, formatCell(row.Total))
+}
+
+// timesheetTotalRecord returns the formatted cells of the totals per day of a timesheet
+func timesheetTotalRecord(sheet *service.Timesheet, byCategory bool, formatCell func(time.Duration) string) []string {
+	record := []string{"Total"}
+	if byCategory {
+		record = append(record, "")
+	}
+
+	for _, day := range sheet.DayTotals {
+		record = append(record, formatCell(day))
+	}
+
+	return append(record, formatCell(sheet.Total))
+}
+
+// newTimesheet converts a timesheet into its structured output
+func newTimesheet(sheet *service.Timesheet) timesheet {
+	result := timesheet{
+		Days:      make([]string, len(sheet.Days)),
+		Rows:      make([]timesheetRow, len(sheet.Rows)),
+		Absences:  sheet.Absences,
+		DayTotals: sheet.DayTotals,
+		Total:     sheet.Total,
+	}
+
+	for i, day := range sheet.Days {
+		result.Days[i] = formatDateInLocal(day)
+	}
+
+	for i, row := range sheet.Rows {
+		result.Rows[i] = timesheetRow(row)
+	}
+
+	return result
+}
+
+// formatHoursMinutes formats a duration as HH:MM
+func formatHoursMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -14,7 +14,7 @@ func NewUICommand() *cobra.Command {
 		Long:  `Start the web UI for time tracking with interactive charts and analytics.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			server := ui.NewServer(timeService)
+			server := ui.NewServer(timeService, configuredRounding())
 			return server.Start(ctx, port)
 		},
 	}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
@@ -31,6 +32,33 @@ var (
 	defaultWebUIPort                      = 8080
 	defaultBackgroundTrackerAutoStop      = false
 	defaultBackgroundTrackerAutoStopAfter = 120 // in minutes
+	defaultBackgroundTrackerCompliance    = true
+	defaultComplianceRules                = "de"
+	defaultRoundingMode                   = "none"
+	defaultRoundingIncrement              = 15 // in minutes
+	defaultRoundingScope                  = "entry"
+	defaultWeekStart                      = "monday"
+	// defaultWorkSchedule defines the default contracted work hours per weekday
+	defaultWorkSchedule = map[string]float64{
+		"monday":    8,
+		"tuesday":   8,
+		"wednesday": 8,
+		"thursday":  8,
+		"friday":    8,
+		"saturday":  0,
+		"sunday":    0,
+	}
+
+	// weekdays maps the lowercase names of the weekdays to their time.Weekday values
+	weekdays = map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"monday":    time.Monday,
+		"tuesday":   time.Tuesday,
+		"wednesday": time.Wednesday,
+		"thursday":  time.Thursday,
+		"friday":    time.Friday,
+		"saturday":  time.Saturday,
+	}
 )
 
 type Config struct {
@@ -43,8 +71,112 @@ type Config struct {
 	UseBackgroundTracker           bool `mapstructure:"use_background_tracker" yaml:"use_background_tracker"`
 	BackgroundTrackerAutoStop      bool `mapstructure:"background_tracker_auto_stop" yaml:"background_tracker_auto_stop"`
 	BackgroundTrackerAutoStopAfter int  `mapstructure:"background_tracker_auto_stop_after" yaml:"background_tracker_auto_stop_after" validate:"gte=1"`
+	// BackgroundTrackerCompliance enables warnings of the background tracker when the active work violates the compliance rules
+	BackgroundTrackerCompliance bool `mapstructure:"background_tracker_compliance" yaml:"background_tracker_compliance"`
 
 	WebUIPort int `mapstructure:"web_ui_port" yaml:"web_ui_port" validate:"gte=1,lte=65535"`
+
+	// WeekStart defines the first day of a week, e.g. for weekly balances
+	WeekStart string `mapstructure:"week_start" yaml:"week_start" validate:"omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	// WorkSchedule defines the contracted work hours per weekday, which the tracked time is compared against
+	WorkSchedule WorkSchedule `mapstructure:"work_schedule" yaml:"work_schedule"`
+	// ComplianceRules defines the statutory rule set for breaks, rest and maximum work time, e.g. 'de' for Germany
+	ComplianceRules string `mapstructure:"compliance_rules" yaml:"compliance_rules" validate:"omitempty,oneof=de at"`
+	// Compliance overrides the thresholds of the compliance rule set
+	Compliance Compliance `mapstructure:"compliance" yaml:"compliance,omitempty"`
+	// Rounding defines how work time is rounded in lists, reports and exports, projects can override it
+	Rounding Rounding `mapstructure:"rounding" yaml:"rounding"`
+	// ExportProfiles defines named layouts of the CSV export, which are selected with --profile
+	ExportProfiles map[string]ExportProfile `mapstructure:"export_profiles" yaml:"export_profiles,omitempty" validate:"dive"`
+}
+
+// ExportProfile defines the columns and formats of a CSV export. Empty fields keep the format of the default export.
+type ExportProfile struct {
+	// Columns are the exported columns in their order, e.g. id, project, start_time, end_time and work_time
+	Columns []string `mapstructure:"columns" yaml:"columns" validate:"omitempty,dive,oneof=id project_id project start_time end_time category duration pauses pause_time work_time rounded_work_time notes tags billable amount currency"`
+	// Delimiter is the field delimiter, e.g. ';' or a tab
+	Delimiter string `mapstructure:"delimiter" yaml:"delimiter" validate:"omitempty,len=1,excludesall=\"\r\n"`
+	// TimeZone is the IANA time zone of the exported times, e.g. UTC or Europe/Berlin, the local time zone by default
+	TimeZone string `mapstructure:"time_zone" yaml:"time_zone" validate:"omitempty,timezone"`
+	// TimeLayout is the Go layout of the exported times, e.g. 2006-01-02T15:04:05Z07:00 for ISO 8601
+	TimeLayout string `mapstructure:"time_layout" yaml:"time_layout"`
+	// DurationFormat is the format of durations: hh:mm:ss, decimal hours, minutes or seconds
+	DurationFormat string `mapstructure:"duration_format" yaml:"duration_format" validate:"omitempty,oneof=hms decimal minutes seconds"`
+}
+
+// Rounding defines how work time is rounded to an increment, the stored times are never changed
+type Rounding struct {
+	// Mode is the rounding direction, 'none' disables rounding
+	Mode string `mapstructure:"mode" yaml:"mode" validate:"omitempty,oneof=none up down nearest"`
+	// Increment is the rounding increment in minutes, e.g. 6 or 15
+	Increment int `mapstructure:"increment" yaml:"increment" validate:"omitempty,gte=1,lte=1440"`
+	// Scope defines if the work time is rounded per entry, per project and day or per project over a whole report
+	Scope string `mapstructure:"scope" yaml:"scope" validate:"omitempty,oneof=entry day report"`
+}
+
+// Compliance overrides the thresholds of the compliance rule set, all values are in minutes. Unset fields keep the
+// thresholds of the rule set.
+type Compliance struct {
+	// Breaks replace the break rules of the rule set
+	Breaks []ComplianceBreak `mapstructure:"breaks" yaml:"breaks,omitempty" validate:"omitempty,dive"`
+	// MinBreak is the shortest interruption of work which counts as a break
+	MinBreak int `mapstructure:"min_break" yaml:"min_break,omitempty" validate:"omitempty,gte=1,lte=1440"`
+	// MinRest is the minimum rest between two working days
+	MinRest int `mapstructure:"min_rest" yaml:"min_rest,omitempty" validate:"omitempty,gte=1,lte=1440"`
+	// MaxDailyWork is the maximum work time of a day
+	MaxDailyWork int `mapstructure:"max_daily_work" yaml:"max_daily_work,omitempty" validate:"omitempty,gte=1,lte=1440"`
+}
+
+// ComplianceBreak requires a minimum total break once the work time of a day exceeds a threshold
+type ComplianceBreak struct {
+	// After is the daily work time in minutes from which the break is required
+	After int `mapstructure:"after" yaml:"after" validate:"gte=0,lte=1440"`
+	// Minimum is the required total break in minutes
+	Minimum int `mapstructure:"minimum" yaml:"minimum" validate:"gte=1,lte=1440"`
+}
+
+// WorkSchedule defines the contracted work hours per weekday
+type WorkSchedule struct {
+	Monday    float64 `mapstructure:"monday" yaml:"monday" validate:"gte=0,lte=24"`
+	Tuesday   float64 `mapstructure:"tuesday" yaml:"tuesday" validate:"gte=0,lte=24"`
+	Wednesday float64 `mapstructure:"wednesday" yaml:"wednesday" validate:"gte=0,lte=24"`
+	Thursday  float64 `mapstructure:"thursday" yaml:"thursday" validate:"gte=0,lte=24"`
+	Friday    float64 `mapstructure:"friday" yaml:"friday" validate:"gte=0,lte=24"`
+	Saturday  float64 `mapstructure:"saturday" yaml:"saturday" validate:"gte=0,lte=24"`
+	Sunday    float64 `mapstructure:"sunday" yaml:"sunday" validate:"gte=0,lte=24"`
+}
+
+// Expected returns the contracted work time of the given weekday
+func (s WorkSchedule) Expected(weekday time.Weekday) time.Duration {
+	var hours float64
+
+	switch weekday {
+	case time.Monday:
+		hours = s.Monday
+	case time.Tuesday:
+		hours = s.Tuesday
+	case time.Wednesday:
+		hours = s.Wednesday
+	case time.Thursday:
+		hours = s.Thursday
+	case time.Friday:
+		hours = s.Friday
+	case time.Saturday:
+		hours = s.Saturday
+	case time.Sunday:
+		hours = s.Sunday
+	}
+
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// WeekStartDay returns the configured first day of a week, Monday by default
+func (c *Config) WeekStartDay() time.Weekday {
+	if weekday, ok := weekdays[c.WeekStart]; ok {
+		return weekday
+	}
+
+	return time.Monday
 }
 
 // Load loads the configuration from the specified file or default locations.
@@ -63,6 +195,15 @@ func Load(configFile string) (*Config, string, error) {
 	viper.SetDefault("web_ui_port", defaultWebUIPort)
 	viper.SetDefault("background_tracker_auto_stop", defaultBackgroundTrackerAutoStop)
 	viper.SetDefault("background_tracker_auto_stop_after", defaultBackgroundTrackerAutoStopAfter)
+	viper.SetDefault("background_tracker_compliance", defaultBackgroundTrackerCompliance)
+	viper.SetDefault("week_start", defaultWeekStart)
+	for weekday, hours := range defaultWorkSchedule {
+		viper.SetDefault("work_schedule."+weekday, hours)
+	}
+	viper.SetDefault("compliance_rules", defaultComplianceRules)
+	viper.SetDefault("rounding.mode", defaultRoundingMode)
+	viper.SetDefault("rounding.increment", defaultRoundingIncrement)
+	viper.SetDefault("rounding.scope", defaultRoundingScope)
 
 	viper.SetConfigType("yaml")
 
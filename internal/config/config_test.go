@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -305,3 +306,225 @@ func TestValidateConfig_WithMultipleValidationErrors(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "validation errors")
 }
+
+func TestLoad_WithWorkSchedule(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `week_start: sunday
+work_schedule:
+  monday: 7.5
+  friday: 4`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, time.Sunday, cfg.WeekStartDay())
+	assert.Equal(t, 7*time.Hour+30*time.Minute, cfg.WorkSchedule.Expected(time.Monday))
+	assert.Equal(t, 8*time.Hour, cfg.WorkSchedule.Expected(time.Tuesday))
+	assert.Equal(t, 4*time.Hour, cfg.WorkSchedule.Expected(time.Friday))
+	assert.Zero(t, cfg.WorkSchedule.Expected(time.Saturday))
+}
+
+func TestLoad_WithDefaultWorkSchedule(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	err := os.WriteFile(configPath, []byte(""), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, time.Monday, cfg.WeekStartDay())
+	assert.Equal(t, 8*time.Hour, cfg.WorkSchedule.Expected(time.Wednesday))
+	assert.Zero(t, cfg.WorkSchedule.Expected(time.Sunday))
+}
+
+func TestValidateConfig_WithInvalidWorkSchedule(t *testing.T) {
+	cfg := &Config{
+		DatabaseDir:                    "/tmp/test",
+		ListLimit:                      50,
+		ListOrder:                      "asc",
+		WebUIPort:                      8080,
+		BackgroundTrackerAutoStopAfter: 60,
+		WeekStart:                      "someday",
+		WorkSchedule:                   WorkSchedule{Monday: 25},
+	}
+
+	err := validateConfig(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validation errors")
+}
+
+func TestLoad_WithDefaultComplianceRules(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	err := os.WriteFile(configPath, []byte(""), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "de", cfg.ComplianceRules)
+	assert.True(t, cfg.BackgroundTrackerCompliance)
+}
+
+func TestValidateConfig_WithInvalidComplianceRules(t *testing.T) {
+	cfg := &Config{
+		DatabaseDir:                    "/tmp/test",
+		ListLimit:                      50,
+		ListOrder:                      "asc",
+		WebUIPort:                      8080,
+		BackgroundTrackerAutoStopAfter: 60,
+		ComplianceRules:                "xx",
+	}
+
+	err := validateConfig(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error")
+}
+
+func TestLoad_WithComplianceOverrides(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `compliance_rules: at
+compliance:
+  breaks:
+    - after: 300
+      minimum: 20
+  max_daily_work: 480`
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "at", cfg.ComplianceRules)
+	assert.Equal(t, []ComplianceBreak{{After: 300, Minimum: 20}}, cfg.Compliance.Breaks)
+	assert.Equal(t, 480, cfg.Compliance.MaxDailyWork)
+	assert.Zero(t, cfg.Compliance.MinBreak)
+	assert.Zero(t, cfg.Compliance.MinRest)
+}
+
+func TestValidateConfig_WithInvalidComplianceOverrides(t *testing.T) {
+	cfg := &Config{
+		DatabaseDir:                    "/tmp/test",
+		ListLimit:                      50,
+		ListOrder:                      "asc",
+		WebUIPort:                      8080,
+		BackgroundTrackerAutoStopAfter: 60,
+		Compliance:                     Compliance{Breaks: []ComplianceBreak{{After: 360, Minimum: 0}}},
+	}
+
+	err := validateConfig(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error")
+}
+
+func TestLoad_WithRounding(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `rounding:
+  mode: up
+  increment: 6`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "up", cfg.Rounding.Mode)
+	assert.Equal(t, 6, cfg.Rounding.Increment)
+	assert.Equal(t, "entry", cfg.Rounding.Scope)
+}
+
+func TestValidateConfig_WithInvalidRounding(t *testing.T) {
+	cfg := &Config{
+		DatabaseDir:                    "/tmp/test",
+		ListLimit:                      50,
+		ListOrder:                      "asc",
+		WebUIPort:                      8080,
+		BackgroundTrackerAutoStopAfter: 60,
+		Rounding:                       Rounding{Mode: "sideways", Increment: 15, Scope: "week"},
+	}
+
+	err := validateConfig(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validation errors")
+}
+
+func TestLoad_WithExportProfiles(t *testing.T) {
+	defer resetViper()
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `export_profiles:
+  Payroll:
+    columns: [id, project_id, start_time, work_time]
+    delimiter: ";"
+    time_zone: UTC
+    time_layout: "2006-01-02T15:04:05Z07:00"
+    duration_format: decimal`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(configPath)
+	require.NoError(t, err)
+	// Viper lowercases the keys, so profile names are case-insensitive
+	assert.Equal(t, map[string]ExportProfile{
+		"payroll": {
+			Columns:        []string{"id", "project_id", "start_time", "work_time"},
+			Delimiter:      ";",
+			TimeZone:       "UTC",
+			TimeLayout:     "2006-01-02T15:04:05Z07:00",
+			DurationFormat: "decimal",
+		},
+	}, cfg.ExportProfiles)
+}
+
+func TestValidateConfig_WithInvalidExportProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ExportProfile
+	}{
+		{"unknown column", ExportProfile{Columns: []string{"id", "salary"}}},
+		{"long delimiter", ExportProfile{Delimiter: ";;"}},
+		{"newline delimiter", ExportProfile{Delimiter: "\n"}},
+		{"unknown time zone", ExportProfile{TimeZone: "Mars/Olympus_Mons"}},
+		{"unknown duration format", ExportProfile{DurationFormat: "days"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				DatabaseDir:                    "/tmp/test",
+				ListLimit:                      50,
+				ListOrder:                      "asc",
+				WebUIPort:                      8080,
+				BackgroundTrackerAutoStopAfter: 60,
+				ExportProfiles:                 map[string]ExportProfile{"payroll": tt.profile},
+			}
+
+			assert.Error(t, validateConfig(cfg))
+		})
+	}
+
+	cfg := &Config{
+		DatabaseDir:                    "/tmp/test",
+		ListLimit:                      50,
+		ListOrder:                      "asc",
+		WebUIPort:                      8080,
+		BackgroundTrackerAutoStopAfter: 60,
+		ExportProfiles:                 map[string]ExportProfile{"payroll": {Delimiter: "\t", TimeZone: "Europe/Berlin", DurationFormat: "minutes"}},
+	}
+	assert.NoError(t, validateConfig(cfg))
+}
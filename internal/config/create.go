@@ -40,6 +40,15 @@ func CreateDefault(directory string, forceOverwrite bool) (string, error) {
 	viper.Set("web_ui_port", defaultWebUIPort)
 	viper.Set("background_tracker_auto_stop", defaultBackgroundTrackerAutoStop)
 	viper.Set("background_tracker_auto_stop_after", defaultBackgroundTrackerAutoStopAfter)
+	viper.Set("background_tracker_compliance", defaultBackgroundTrackerCompliance)
+	viper.Set("week_start", defaultWeekStart)
+	for weekday, hours := range defaultWorkSchedule {
+		viper.Set("work_schedule."+weekday, hours)
+	}
+	viper.Set("compliance_rules", defaultComplianceRules)
+	viper.Set("rounding.mode", defaultRoundingMode)
+	viper.Set("rounding.increment", defaultRoundingIncrement)
+	viper.Set("rounding.scope", defaultRoundingScope)
 
 	configFilepath := path.Join(directory, FileName)
 
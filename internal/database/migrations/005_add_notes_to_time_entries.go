@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		// Add notes column to time_entries table
+		query := `ALTER TABLE time_entries ADD COLUMN notes TEXT;`
+		_, err := tx.ExecContext(ctx, query)
+		return err
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		// SQLite doesn't support DROP COLUMN directly, so we need to recreate the table
+		query := `
+		CREATE TABLE time_entries_new (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL,
+			start_time DATETIME NOT NULL,
+			end_time DATETIME,
+			duration INTEGER, -- Duration in seconds
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			category VARCHAR(50),
+			FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+		);
+		
+		INSERT INTO time_entries_new (id, project_id, start_time, end_time, duration, created_at, category)
+		SELECT id, project_id, start_time, end_time, duration, created_at, category FROM time_entries;
+		
+		DROP TABLE time_entries;
+		ALTER TABLE time_entries_new RENAME TO time_entries;
+		
+		-- Recreate indexes
+		CREATE INDEX IF NOT EXISTS idx_time_entries_project_id ON time_entries(project_id);
+		CREATE INDEX IF NOT EXISTS idx_time_entries_start_time ON time_entries(start_time);
+		CREATE INDEX IF NOT EXISTS idx_time_entries_category ON time_entries(category);
+		`
+		_, err := tx.ExecContext(ctx, query)
+		return err
+	}
+
+	// Register the migration
+	AddMigration("005_add_notes_to_time_entries", up, down)
+}
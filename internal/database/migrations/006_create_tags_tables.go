@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		// Create tags table
+		query := `
+		CREATE TABLE IF NOT EXISTS tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name VARCHAR(50) NOT NULL UNIQUE,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`
+
+		_, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		// Create link table between time entries and tags
+		linkQuery := `
+		CREATE TABLE IF NOT EXISTS time_entry_tags (
+			time_entry_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			PRIMARY KEY (time_entry_id, tag_id),
+			FOREIGN KEY (time_entry_id) REFERENCES time_entries(id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		);`
+
+		_, err = tx.ExecContext(ctx, linkQuery)
+		if err != nil {
+			return err
+		}
+
+		// Create index on tag_id for filtering by tag
+		indexQuery := `CREATE INDEX IF NOT EXISTS idx_time_entry_tags_tag_id ON time_entry_tags(tag_id);`
+		_, err = tx.ExecContext(ctx, indexQuery)
+		return err
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		// Drop index first
+		_, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS idx_time_entry_tags_tag_id;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DROP TABLE IF EXISTS time_entry_tags;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DROP TABLE IF EXISTS tags;`)
+		return err
+	}
+
+	// Register the migration
+	AddMigration("006_create_tags_tables", up, down)
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		queries := []string{
+			// Add hourly rate and currency columns to projects table
+			`ALTER TABLE projects ADD COLUMN hourly_rate REAL;`,
+			`ALTER TABLE projects ADD COLUMN currency VARCHAR(3);`,
+			// Add billable flag to time_entries table, existing entries stay billable
+			`ALTER TABLE time_entries ADD COLUMN billable BOOLEAN NOT NULL DEFAULT 1;`,
+			// Create table for hourly rates which override the project rate for a category
+			`CREATE TABLE IF NOT EXISTS category_rates (
+				project_id INTEGER NOT NULL,
+				category VARCHAR(50) NOT NULL,
+				hourly_rate REAL NOT NULL,
+				PRIMARY KEY (project_id, category),
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			);`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		// The bundled SQLite version supports DROP COLUMN, which keeps the foreign keys of the tables intact
+		queries := []string{
+			`DROP TABLE IF EXISTS category_rates;`,
+			`ALTER TABLE time_entries DROP COLUMN billable;`,
+			`ALTER TABLE projects DROP COLUMN currency;`,
+			`ALTER TABLE projects DROP COLUMN hourly_rate;`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Register the migration
+	AddMigration("007_add_billing", up, down)
+}
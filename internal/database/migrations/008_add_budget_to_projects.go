@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		queries := []string{
+			// Add time budget (in seconds) and its period to projects table
+			`ALTER TABLE projects ADD COLUMN budget INTEGER;`,
+			`ALTER TABLE projects ADD COLUMN budget_period VARCHAR(10);`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		queries := []string{
+			`ALTER TABLE projects DROP COLUMN budget_period;`,
+			`ALTER TABLE projects DROP COLUMN budget;`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Register the migration
+	AddMigration("008_add_budget_to_projects", up, down)
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		// Create absences table, the date is stored as YYYY-MM-DD since absences refer to local calendar days
+		query := `
+		CREATE TABLE IF NOT EXISTS absences (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			date VARCHAR(10) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			half_day BOOLEAN NOT NULL DEFAULT 0,
+			note TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`
+
+		_, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		// Create index on date for range queries
+		indexQuery := `CREATE INDEX IF NOT EXISTS idx_absences_date ON absences(date);`
+		_, err = tx.ExecContext(ctx, indexQuery)
+		return err
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		// Drop index first
+		_, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS idx_absences_date;`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DROP TABLE IF EXISTS absences;`)
+		return err
+	}
+
+	// Register the migration
+	AddMigration("009_create_absences_table", up, down)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func init() {
+	up := func(ctx context.Context, tx *sql.Tx) error {
+		queries := []string{
+			// Add the rounding rule overriding the configured rounding (increment in seconds) to projects table
+			`ALTER TABLE projects ADD COLUMN rounding_mode VARCHAR(10);`,
+			`ALTER TABLE projects ADD COLUMN rounding_increment INTEGER;`,
+			`ALTER TABLE projects ADD COLUMN rounding_scope VARCHAR(10);`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, tx *sql.Tx) error {
+		queries := []string{
+			`ALTER TABLE projects DROP COLUMN rounding_scope;`,
+			`ALTER TABLE projects DROP COLUMN rounding_increment;`,
+			`ALTER TABLE projects DROP COLUMN rounding_mode;`,
+		}
+
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Register the migration
+	AddMigration("010_add_rounding_to_projects", up, down)
+}
@@ -0,0 +1,193 @@
+package daterange
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is a period of time with an inclusive start and an exclusive end. Expressions which denote a point in time
+// have the same start and end.
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+var (
+	// timestampLayouts defines the accepted layouts for points in time
+	timestampLayouts = []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		time.RFC3339,
+	}
+
+	isoWeekPattern  = regexp.MustCompile(`^(\d{4})-w(\d{1,2})$`)
+	agoPattern      = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+	relativePattern = regexp.MustCompile(`^(this|last|next)\s+(week|month|year)$`)
+)
+
+// ParseRange parses a date expression or two date expressions separated by "..", e.g. "2025-10-01..2025-10-15",
+// where the range lasts from the start of the first until the end of the second expression
+func ParseRange(expr string, now time.Time, weekStart time.Weekday) (Range, error) {
+	from, until, found := strings.Cut(expr, "..")
+	if !found {
+		return Parse(expr, now, weekStart)
+	}
+
+	start, err := Parse(from, now, weekStart)
+	if err != nil {
+		return Range{}, err
+	}
+
+	end, err := Parse(until, now, weekStart)
+	if err != nil {
+		return Range{}, err
+	}
+
+	if end.End.Before(start.Start) {
+		return Range{}, fmt.Errorf("invalid range %q, the end is before the start", expr)
+	}
+
+	return Range{Start: start.Start, End: end.End}, nil
+}
+
+// Parse parses a date expression relative to the given time in its location. Supported are dates (2025-10-16),
+// months (2025-10), ISO weeks (2025-W42), timestamps (2025-10-16 09:30), the keywords now, today, yesterday and
+// tomorrow, this/last/next week, month or year, and relative times like "2h ago" or "3 days ago".
+func Parse(expr string, now time.Time, weekStart time.Weekday) (Range, error) {
+	value := strings.Join(strings.Fields(strings.ToLower(expr)), " ")
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch value {
+	case "":
+		return Range{}, fmt.Errorf("empty date expression")
+	case "now":
+		return Range{Start: now, End: now}, nil
+	case "today":
+		return day(today), nil
+	case "yesterday":
+		return day(today.AddDate(0, 0, -1)), nil
+	case "tomorrow":
+		return day(today.AddDate(0, 0, 1)), nil
+	}
+
+	if match := relativePattern.FindStringSubmatch(value); match != nil {
+		offset := map[string]int{"this": 0, "last": -1, "next": 1}[match[1]]
+
+		switch match[2] {
+		case "week":
+			return Week(today.AddDate(0, 0, 7*offset), weekStart), nil
+		case "month":
+			return Month(time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)), nil
+		default:
+			start := time.Date(today.Year()+offset, time.January, 1, 0, 0, 0, 0, loc)
+			return Range{Start: start, End: start.AddDate(1, 0, 0)}, nil
+		}
+	}
+
+	if match := agoPattern.FindStringSubmatch(value); match != nil {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid date expression %q", expr)
+		}
+
+		t, err := subtract(now, amount, match[2])
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid date expression %q: %w", expr, err)
+		}
+
+		return Range{Start: t, End: t}, nil
+	}
+
+	if match := isoWeekPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+
+		start, err := isoWeekStart(year, week, loc)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid date expression %q: %w", expr, err)
+		}
+
+		return Range{Start: start, End: start.AddDate(0, 0, 7)}, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return day(t), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01", value, loc); err == nil {
+		return Range{Start: t, End: t.AddDate(0, 1, 0)}, nil
+	}
+
+	for _, layout := range timestampLayouts {
+		// The layouts are matched against the original value, as RFC 3339 requires an upper case T and Z
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(expr), loc); err == nil {
+			return Range{Start: t, End: t}, nil
+		}
+	}
+
+	return Range{}, fmt.Errorf("invalid date expression %q. Use a date like 2025-10-16, a week like 2025-W42, a timestamp like \"2025-10-16 09:30\", today, yesterday, last week, this month or 2h ago", expr)
+}
+
+// day returns the range of the day starting at the given time
+func day(start time.Time) Range {
+	return Range{Start: start, End: start.AddDate(0, 0, 1)}
+}
+
+// Day returns the day which contains the given time in its location
+func Day(t time.Time) Range {
+	return day(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+}
+
+// Week returns the week which contains the given time in its location, starting on the given weekday
+func Week(t time.Time, weekStart time.Weekday) Range {
+	start := Day(t).Start
+	start = start.AddDate(0, 0, -((int(start.Weekday()) - int(weekStart) + 7) % 7))
+	return Range{Start: start, End: start.AddDate(0, 0, 7)}
+}
+
+// Month returns the calendar month which contains the given time in its location
+func Month(t time.Time) Range {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return Range{Start: start, End: start.AddDate(0, 1, 0)}
+}
+
+// subtract subtracts the given amount of a unit from a time
+func subtract(t time.Time, amount int, unit string) (time.Time, error) {
+	switch unit {
+	case "s", "sec", "secs", "second", "seconds":
+		return t.Add(-time.Duration(amount) * time.Second), nil
+	case "m", "min", "mins", "minute", "minutes":
+		return t.Add(-time.Duration(amount) * time.Minute), nil
+	case "h", "hr", "hrs", "hour", "hours":
+		return t.Add(-time.Duration(amount) * time.Hour), nil
+	case "d", "day", "days":
+		return t.AddDate(0, 0, -amount), nil
+	case "w", "week", "weeks":
+		return t.AddDate(0, 0, -7*amount), nil
+	case "mo", "month", "months":
+		return t.AddDate(0, -amount, 0), nil
+	case "y", "year", "years":
+		return t.AddDate(-amount, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown unit %q", unit)
+	}
+}
+
+// isoWeekStart returns the Monday of the given ISO 8601 week
+func isoWeekStart(year int, week int, loc *time.Location) (time.Time, error) {
+	// January 4th is always in the first ISO week of its year
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	start := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+7*(week-1))
+
+	if _, isoWeek := start.ISOWeek(); week < 1 || isoWeek != week {
+		return time.Time{}, fmt.Errorf("week %d does not exist in %d", week, year)
+	}
+
+	return start, nil
+}
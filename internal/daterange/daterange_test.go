@@ -0,0 +1,90 @@
+package daterange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	// Friday, 2025-10-17
+	now := time.Date(2025, 10, 17, 14, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr     string
+		expected Range
+	}{
+		{"now", Range{Start: now, End: now}},
+		{"today", Range{Start: date(2025, 10, 17), End: date(2025, 10, 18)}},
+		{"Yesterday", Range{Start: date(2025, 10, 16), End: date(2025, 10, 17)}},
+		{"this week", Range{Start: date(2025, 10, 13), End: date(2025, 10, 20)}},
+		{"last  week", Range{Start: date(2025, 10, 6), End: date(2025, 10, 13)}},
+		{"this month", Range{Start: date(2025, 10, 1), End: date(2025, 11, 1)}},
+		{"last month", Range{Start: date(2025, 9, 1), End: date(2025, 10, 1)}},
+		{"next year", Range{Start: date(2026, 1, 1), End: date(2027, 1, 1)}},
+		{"2h ago", Range{Start: now.Add(-2 * time.Hour), End: now.Add(-2 * time.Hour)}},
+		{"3 days ago", Range{Start: now.AddDate(0, 0, -3), End: now.AddDate(0, 0, -3)}},
+		{"2025-W42", Range{Start: date(2025, 10, 13), End: date(2025, 10, 20)}},
+		{"2026-W01", Range{Start: date(2025, 12, 29), End: date(2026, 1, 5)}},
+		{"2025-10-01", Range{Start: date(2025, 10, 1), End: date(2025, 10, 2)}},
+		{"2025-02", Range{Start: date(2025, 2, 1), End: date(2025, 3, 1)}},
+		{"2025-10-16 09:30", Range{Start: time.Date(2025, 10, 16, 9, 30, 0, 0, time.UTC), End: time.Date(2025, 10, 16, 9, 30, 0, 0, time.UTC)}},
+		{"2025-10-16T09:30:15", Range{Start: time.Date(2025, 10, 16, 9, 30, 15, 0, time.UTC), End: time.Date(2025, 10, 16, 9, 30, 15, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			r, err := Parse(tt.expr, now, time.Monday)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r)
+		})
+	}
+}
+
+func TestParse_WeekStart(t *testing.T) {
+	now := time.Date(2025, 10, 17, 14, 30, 0, 0, time.UTC)
+
+	r, err := Parse("this week", now, time.Sunday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC), r.Start)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Now()
+
+	for _, expr := range []string{"", "someday", "2 fortnights ago", "2025-W54", "2025-13-01"} {
+		_, err := Parse(expr, now, time.Monday)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	now := time.Date(2025, 10, 17, 14, 30, 0, 0, time.UTC)
+
+	r, err := ParseRange("2025-10-01..2025-10-15", now, time.Monday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), r.Start)
+	assert.Equal(t, time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC), r.End)
+
+	r, err = ParseRange("last week", now, time.Monday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC), r.Start)
+
+	_, err = ParseRange("2025-10-15..2025-10-01", now, time.Monday)
+	assert.Error(t, err)
+}
+
+func TestPeriods(t *testing.T) {
+	// Wednesday, 2025-10-15
+	at := time.Date(2025, 10, 15, 23, 59, 0, 0, time.UTC)
+
+	assert.Equal(t, Range{Start: time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)}, Day(at))
+	assert.Equal(t, Range{Start: time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)}, Week(at, time.Monday))
+	assert.Equal(t, time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC), Week(at, time.Sunday).Start)
+	assert.Equal(t, Range{Start: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)}, Month(at))
+}
@@ -0,0 +1,240 @@
+// Package ics implements the parts of the iCalendar format (RFC 5545) which hora needs to read and write events.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout        = "20060102"
+	dateTimeLayout    = "20060102T150405"
+	dateTimeUTCLayout = "20060102T150405Z"
+)
+
+// Event represents a calendar event
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	// Start and End of the event, End is exclusive. All-day events start and end at midnight in the local timezone.
+	Start  time.Time
+	End    time.Time
+	AllDay bool
+}
+
+// Days returns the start of each local calendar day the event covers
+func (e Event) Days() []time.Time {
+	start := e.Start.Local()
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+
+	days := []time.Time{day}
+	for day = day.AddDate(0, 0, 1); day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// Parse reads all events of an iCalendar stream
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+	)
+
+	for number, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", number+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no start", number+1, current.Summary)
+			}
+			if current.End.IsZero() || !current.End.After(current.Start) {
+				current.End = current.Start
+				if current.AllDay {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescapeText(value)
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "DTSTART", name == "DTEND":
+			t, allDay, err := parseDateTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", number+1, name, err)
+			}
+			if name == "DTSTART" {
+				current.Start = t
+				current.AllDay = allDay
+			} else {
+				current.End = t
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Write writes the events as an iCalendar stream. Times are written in UTC, all-day events as dates. The stamp is the
+// time the calendar was created.
+func Write(w io.Writer, events []Event, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//hora//hora//EN",
+		"CALSCALE:GREGORIAN",
+	}
+
+	for _, event := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeText(event.UID),
+			"DTSTAMP:"+stamp.UTC().Format(dateTimeUTCLayout),
+		)
+
+		if event.AllDay {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+event.Start.Format(dateLayout),
+				"DTEND;VALUE=DATE:"+event.End.Format(dateLayout),
+			)
+		} else {
+			lines = append(lines,
+				"DTSTART:"+event.Start.UTC().Format(dateTimeUTCLayout),
+				"DTEND:"+event.End.UTC().Format(dateTimeUTCLayout),
+			)
+		}
+
+		lines = append(lines, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.WriteString(foldLine(line))
+		buf.WriteString("\r\n")
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// unfoldLines reads the content lines of an iCalendar stream, joining folded lines
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitProperty splits a content line into its upper case name, its parameters and its value
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string)
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseDateTime parses a DATE or DATE-TIME value and reports whether it is a date without time
+func parseDateTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, time.Local)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeUTCLayout, value)
+		return t, false, err
+	}
+
+	location := time.Local
+	if tzid, ok := params["TZID"]; ok {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	return t, false, err
+}
+
+// unescapeText reverts the escaping of TEXT values
+func unescapeText(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
+
+// escapeText escapes the special characters of TEXT values
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// foldLine splits a content line into lines of at most 75 octets, continuation lines start with a space. Multi-byte
+// characters are not split.
+func foldLine(line string) string {
+	const limit = 75
+
+	var buf strings.Builder
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > limit {
+			buf.WriteString("\r\n ")
+			length = 1
+		}
+		buf.WriteRune(r)
+		length += size
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,109 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:christmas-2025",
+		"DTSTART;VALUE=DATE:20251225",
+		"DTEND;VALUE=DATE:20251227",
+		"SUMMARY:Christmas Day\\, Boxing",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20251231",
+		"SUMMARY:New Year's Eve",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20251001T080000Z",
+		"DTEND:20251001T100000Z",
+		"SUMMARY:Meeting",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := Parse(strings.NewReader(calendar))
+
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "christmas-2025", events[0].UID)
+	assert.Equal(t, "Christmas Day, Boxing Day", events[0].Summary)
+	assert.True(t, events[0].AllDay)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local),
+		time.Date(2025, 12, 26, 0, 0, 0, 0, time.Local),
+	}, events[0].Days())
+
+	// All-day events without an end last one day
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), events[1].End)
+	assert.Len(t, events[1].Days(), 1)
+
+	assert.False(t, events[2].AllDay)
+	assert.Equal(t, time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC), events[2].Start.UTC())
+	assert.Equal(t, 2*time.Hour, events[2].End.Sub(events[2].Start))
+}
+
+func TestParse_InvalidDate(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:2025-12-25\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	events, err := Parse(strings.NewReader(calendar))
+
+	assert.Error(t, err)
+	assert.Nil(t, events)
+}
+
+func TestWrite(t *testing.T) {
+	stamp := time.Date(2025, 10, 22, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 10, 21, 9, 0, 0, 0, time.UTC)
+	events := []Event{
+		{
+			UID:         "hora-entry-9@hora",
+			Summary:     "Client, Inc.",
+			Description: "Category: dev\nNotes: " + strings.Repeat("ä", 40),
+			Start:       start,
+			End:         start.Add(3 * time.Hour),
+		},
+		{
+			UID:     "holiday",
+			Summary: "Holiday",
+			Start:   time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local),
+			End:     time.Date(2025, 12, 26, 0, 0, 0, 0, time.Local),
+			AllDay:  true,
+		},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, Write(&buf, events, stamp))
+
+	calendar := buf.String()
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Contains(t, calendar, "DTSTAMP:20251022T080000Z\r\n")
+	assert.Contains(t, calendar, "DTSTART:20251021T090000Z\r\nDTEND:20251021T120000Z\r\n")
+	assert.Contains(t, calendar, "SUMMARY:Client\\, Inc.\r\n")
+	assert.Contains(t, calendar, "DTSTART;VALUE=DATE:20251225\r\n")
+
+	for _, line := range strings.Split(calendar, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+
+	parsed, err := Parse(strings.NewReader(calendar))
+
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	assert.Equal(t, events[0].UID, parsed[0].UID)
+	assert.Equal(t, events[0].Summary, parsed[0].Summary)
+	assert.Equal(t, events[0].Description, parsed[0].Description)
+	assert.True(t, events[0].End.Equal(parsed[0].End))
+	assert.Equal(t, events[1].Start, parsed[1].Start)
+}
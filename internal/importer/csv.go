@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// parseDetailedCSV reads the detailed report CSV export of Toggl Track or Clockify. Both use the same columns, only
+// the case of the names and the date layout differ: the project, the task which becomes the category, the description
+// which becomes the notes, comma separated tags, the billable flag and the start and end date and time.
+func parseDetailedCSV(r io.Reader) ([]Entry, error) {
+	records, err := csvRecords(r, "start date", "start time", "end time")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for i, record := range records {
+		if record["end time"] == "" {
+			// Running timers have no end
+			continue
+		}
+
+		start, end, err := parseCSVInterval(record, "start date", "start time", "end date", "end time")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+
+		entries = append(entries, Entry{
+			Project:  record["project"],
+			Start:    start,
+			End:      end,
+			Category: NormalizeName(record["task"]),
+			Notes:    record["description"],
+			Tags:     normalizeNames(strings.Split(record["tags"], ",")),
+			Billable: parseBillable(record["billable"]),
+		})
+	}
+
+	return entries, nil
+}
+
+// ParseCSV reads the CSV export of hora ('hora export' or 'hora project export-times'). Only the start and end time
+// are required, the project column is optional for exports of a single project. Empty values are exported as "-".
+// The pause time is read as the total time of the pauses, as the export has no single pauses.
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	records, err := csvRecords(r, "start time", "end time")
+	if err != nil {
+		return nil, err
+	}
+
+	value := func(record map[string]string, column string) string {
+		if record[column] == "-" {
+			return ""
+		}
+		return record[column]
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for i, record := range records {
+		if value(record, "end time") == "" {
+			// The entry was still being tracked
+			continue
+		}
+
+		start, err := time.ParseInLocation(time.DateTime, record["start time"], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid start time %q", i+2, record["start time"])
+		}
+
+		end, err := time.ParseInLocation(time.DateTime, record["end time"], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid end time %q", i+2, record["end time"])
+		}
+
+		var pauseTime time.Duration
+		if pause := value(record, "pause time"); pause != "" {
+			pauseTime, err = parseClockDuration(pause)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+		}
+
+		var tags []string
+		if value(record, "tags") != "" {
+			tags = normalizeNames(strings.Split(record["tags"], ","))
+		}
+
+		entries = append(entries, Entry{
+			Project:   value(record, "project"),
+			Start:     start,
+			End:       end,
+			Category:  NormalizeName(value(record, "category")),
+			Notes:     value(record, "notes"),
+			Tags:      tags,
+			Billable:  parseBillable(record["billable"]),
+			PauseTime: pauseTime,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseClockDuration parses a duration in the HH:MM:SS format of the hora export
+func parseClockDuration(value string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
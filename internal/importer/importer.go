@@ -0,0 +1,163 @@
+// Package importer reads the time entries which other time trackers export, so they can be imported into hora.
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Supported import formats
+const (
+	FormatTogglCSV    = "toggl-csv"
+	FormatClockifyCSV = "clockify-csv"
+	FormatTimewarrior = "timewarrior"
+)
+
+// Formats lists all supported import formats
+var Formats = []string{FormatTogglCSV, FormatClockifyCSV, FormatTimewarrior}
+
+// Entry is a completed time entry read from an export. Category and tags are already normalized to valid hora names,
+// Project is empty if the source has no project for the entry. PauseTime is the total time of the pauses within the
+// entry, if the source only knows their sum.
+type Entry struct {
+	Project   string
+	Start     time.Time
+	End       time.Time
+	Category  string
+	Notes     string
+	Tags      []string
+	Billable  bool
+	PauseTime time.Duration
+}
+
+// Parse reads all completed time entries of an export in the given format. Entries which are still running are
+// skipped.
+func Parse(format string, r io.Reader) ([]Entry, error) {
+	switch format {
+	case FormatTogglCSV, FormatClockifyCSV:
+		return parseDetailedCSV(r)
+	case FormatTimewarrior:
+		return parseTimewarrior(r)
+	default:
+		return nil, fmt.Errorf("invalid import format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_\s-]+`)
+
+// NormalizeName turns a name of another tracker into a valid hora category or tag name by replacing whitespace with
+// hyphens and removing all other characters which are not allowed. It returns an empty string if nothing is left.
+func NormalizeName(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "")
+	return strings.Join(strings.Fields(name), "-")
+}
+
+// normalizeNames normalizes a list of names and removes empty values and duplicates
+func normalizeNames(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name != "" && !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+
+	return normalized
+}
+
+// csvRecords reads a CSV file with a header row and returns its records as maps from the lower case column names to
+// the values. All of the required columns have to be present.
+func csvRecords(r io.Reader, required ...string) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, name := range rows[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	for _, name := range required {
+		if !slices.Contains(header, name) {
+			return nil, fmt.Errorf("CSV file has no %q column", name)
+		}
+	}
+
+	records := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(header))
+		for i, value := range row {
+			if i < len(header) {
+				record[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+var (
+	dateLayouts  = []string{"2006-01-02", "01/02/2006", "02.01.2006", "2006/01/02"}
+	clockLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "03:04 PM", "3:04:05 PM", "3:04 PM"}
+)
+
+// parseDateTime parses a date and a time of day in one of the layouts the trackers use, in the local timezone
+func parseDateTime(date string, clock string) (time.Time, error) {
+	for _, dateLayout := range dateLayouts {
+		for _, clockLayout := range clockLayouts {
+			if t, err := time.ParseInLocation(dateLayout+" "+clockLayout, date+" "+clock, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date and time %q", date+" "+clock)
+}
+
+// parseCSVInterval parses the start and end of a CSV record from the given date and time columns. A missing end date
+// defaults to the start date.
+func parseCSVInterval(record map[string]string, startDate, startTime, endDate, endTime string) (time.Time, time.Time, error) {
+	start, err := parseDateTime(record[startDate], record[startTime])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	date := record[endDate]
+	if date == "" {
+		date = record[startDate]
+	}
+
+	end, err := parseDateTime(date, record[endTime])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end %s is before start %s", end.Format(time.DateTime), start.Format(time.DateTime))
+	}
+
+	return start, end, nil
+}
+
+// parseBillable parses the billable column of a CSV export, entries are billable unless they are marked otherwise
+func parseBillable(value string) bool {
+	switch strings.ToLower(value) {
+	case "no", "false", "0":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_TogglCSV(t *testing.T) {
+	data := "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()\n" +
+		"Jane,jane@example.com,ACME,Website,Code review,Fix the login,Yes,2025-10-16,09:00:00,2025-10-16,10:30:00,01:30:00,\"remote, urgent!\",\n" +
+		"Jane,jane@example.com,,,,Lunch talk,No,2025-10-16,23:30:00,2025-10-17,00:15:00,00:45:00,,\n"
+
+	entries, err := Parse(FormatTogglCSV, strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{
+		Project:  "Website",
+		Start:    time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local),
+		End:      time.Date(2025, 10, 16, 10, 30, 0, 0, time.Local),
+		Category: "Code-review",
+		Notes:    "Fix the login",
+		Tags:     []string{"remote", "urgent"},
+		Billable: true,
+	}, entries[0])
+
+	// Entries without project and spanning midnight
+	assert.Equal(t, "", entries[1].Project)
+	assert.Equal(t, 45*time.Minute, entries[1].End.Sub(entries[1].Start))
+	assert.False(t, entries[1].Billable)
+	assert.Nil(t, entries[1].Tags)
+}
+
+func TestParse_ClockifyCSV(t *testing.T) {
+	data := `"Project","Client","Description","Task","User","Group","Email","Tags","Billable","Start Date","Start Time","End Date","End Time","Duration (h)","Duration (decimal)"
+"Website","ACME","Standup","Meeting","Jane","","jane@example.com","team","Yes","10/16/2025","09:00:00 AM","10/16/2025","09:15:00 AM","00:15:00","0.25"
+"Website","ACME","Running","","Jane","","jane@example.com","","Yes","10/16/2025","01:00:00 PM","","","",""
+`
+
+	entries, err := Parse(FormatClockifyCSV, strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Website", entries[0].Project)
+	assert.Equal(t, "Meeting", entries[0].Category)
+	assert.Equal(t, []string{"team"}, entries[0].Tags)
+	assert.Equal(t, time.Date(2025, 10, 16, 9, 15, 0, 0, time.Local), entries[0].End)
+}
+
+func TestParse_Timewarrior(t *testing.T) {
+	data := `[
+{"id":2,"start":"20251016T090000Z","end":"20251016T110000Z","tags":["Website","code review","remote"],"annotation":"Login"},
+{"id":1,"start":"20251016T120000Z","tags":["Website"]}
+]`
+
+	entries, err := Parse(FormatTimewarrior, strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, Entry{
+		Project:  "Website",
+		Start:    time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC),
+		End:      time.Date(2025, 10, 16, 11, 0, 0, 0, time.UTC),
+		Notes:    "Login",
+		Tags:     []string{"code-review", "remote"},
+		Billable: true,
+	}, entries[0])
+}
+
+func TestParseCSV(t *testing.T) {
+	data := `Start Time,End Time,Project,Category,Duration,Pauses,Pause Time,Effective Work Time,Rounded Work Time,Notes,Tags,Billable,Amount,Currency
+2025-10-21 09:00:00,2025-10-21 12:00:00,Client,dev,03:00:00,1,00:30:00,02:30:00,03:00:00,Login page,"remote,urgent",yes,240.00,USD
+2025-10-21 13:00:00,-,Client,-,-,0,-,-,-,-,-,yes,-,-
+2025-10-21 14:00:00,2025-10-21 15:00:00,Client,-,01:00:00,0,-,01:00:00,01:00:00,-,-,no,-,-
+`
+
+	entries, err := ParseCSV(strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{
+		Project:   "Client",
+		Start:     time.Date(2025, 10, 21, 9, 0, 0, 0, time.Local),
+		End:       time.Date(2025, 10, 21, 12, 0, 0, 0, time.Local),
+		Category:  "dev",
+		Notes:     "Login page",
+		Tags:      []string{"remote", "urgent"},
+		Billable:  true,
+		PauseTime: 30 * time.Minute,
+	}, entries[0])
+	assert.Equal(t, Entry{
+		Project: "Client",
+		Start:   time.Date(2025, 10, 21, 14, 0, 0, 0, time.Local),
+		End:     time.Date(2025, 10, 21, 15, 0, 0, 0, time.Local),
+	}, entries[1])
+
+	// Exports of a single project and of older versions have fewer columns
+	data = `Start Time,End Time,Category,Duration,Pauses,Pause Time,Effective Work Time
+2025-10-21 09:00:00,2025-10-21 12:00:00,-,03:00:00,2,01:15:00,01:45:00
+`
+
+	entries, err = ParseCSV(strings.NewReader(data))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "", entries[0].Project)
+	assert.Equal(t, 75*time.Minute, entries[0].PauseTime)
+	assert.True(t, entries[0].Billable)
+
+	_, err = ParseCSV(strings.NewReader("Start Time,End Time,Pause Time\n2025-10-21 09:00:00,2025-10-21 12:00:00,half an hour\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("harvest", strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = Parse(FormatTogglCSV, strings.NewReader("Project,Description\nWebsite,Login\n"))
+	assert.ErrorContains(t, err, "start date")
+
+	_, err = Parse(FormatTogglCSV, strings.NewReader("Start date,Start time,End time\n2025-10-16,10:00,09:00\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = Parse(FormatTimewarrior, strings.NewReader(`[{"id":1,"start":"2025-10-16","end":"20251016T110000Z"}]`))
+	assert.Error(t, err)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "code-review", NormalizeName("  code  review "))
+	assert.Equal(t, "QA_2", NormalizeName("QA_2 !"))
+	assert.Equal(t, "", NormalizeName("???"))
+}
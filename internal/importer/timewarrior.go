@@ -0,0 +1,62 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const timewarriorLayout = "20060102T150405Z"
+
+// timewarriorInterval is an interval of the JSON output of 'timew export'
+type timewarriorInterval struct {
+	ID         int      `json:"id"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Tags       []string `json:"tags"`
+	Annotation string   `json:"annotation"`
+}
+
+// parseTimewarrior reads the JSON output of 'timew export'. Timewarrior has no projects, so the first tag of an
+// interval becomes the project and the other tags remain tags.
+func parseTimewarrior(r io.Reader) ([]Entry, error) {
+	var intervals []timewarriorInterval
+	if err := json.NewDecoder(r).Decode(&intervals); err != nil {
+		return nil, fmt.Errorf("failed to read Timewarrior export: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(intervals))
+	for _, interval := range intervals {
+		if interval.End == "" {
+			// The interval is still being tracked
+			continue
+		}
+
+		start, err := time.Parse(timewarriorLayout, interval.Start)
+		if err != nil {
+			return nil, fmt.Errorf("interval @%d: invalid start %q", interval.ID, interval.Start)
+		}
+
+		end, err := time.Parse(timewarriorLayout, interval.End)
+		if err != nil {
+			return nil, fmt.Errorf("interval @%d: invalid end %q", interval.ID, interval.End)
+		}
+
+		entry := Entry{
+			Start:    start,
+			End:      end,
+			Notes:    interval.Annotation,
+			Billable: true,
+		}
+
+		if len(interval.Tags) > 0 {
+			entry.Project = interval.Tags[0]
+			entry.Tags = normalizeNames(interval.Tags[1:])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+const (
+	AbsenceTypeVacation = "vacation"
+	AbsenceTypeSick     = "sick"
+	AbsenceTypeHoliday  = "holiday"
+	AbsenceTypeOther    = "other"
+)
+
+// AbsenceTypes lists all valid absence types
+var AbsenceTypes = []string{AbsenceTypeVacation, AbsenceTypeSick, AbsenceTypeHoliday, AbsenceTypeOther}
+
+// Absence represents a full or half day which counts as worked without having time entries
+type Absence struct {
+	ID int `json:"id" db:"id"`
+	// Date is the start of the absent day in the local timezone
+	Date      time.Time `json:"date" db:"date"`
+	Type      string    `json:"type" db:"type"`
+	HalfDay   bool      `json:"half_day" db:"half_day"`
+	Note      *string   `json:"note,omitempty" db:"note"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Fraction returns the part of the day's expected work time which is credited by the absence
+func (a Absence) Fraction() float64 {
+	if a.HalfDay {
+		return 0.5
+	}
+
+	return 1
+}
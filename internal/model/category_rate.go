@@ -0,0 +1,8 @@
+package model
+
+// CategoryRate represents an hourly rate which overrides the project rate for time entries of a category
+type CategoryRate struct {
+	ProjectID  int     `json:"project_id" db:"project_id"`
+	Category   string  `json:"category" db:"category"`
+	HourlyRate float64 `json:"hourly_rate" db:"hourly_rate"`
+}
@@ -2,10 +2,34 @@ package model
 
 import "time"
 
+const (
+	// BudgetPeriodTotal means the budget covers all time tracked for a project
+	BudgetPeriodTotal = "total"
+	// BudgetPeriodMonth means the budget is renewed every calendar month
+	BudgetPeriodMonth = "month"
+)
+
 // Project represents a project in the system
 type Project struct {
-	ID            int        `json:"id" db:"id"`
-	Name          string     `json:"name" db:"name"`
-	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
-	LastTrackedAt *time.Time `json:"last_tracked_at,omitempty" db:"last_tracked_at"`
+	ID            int            `json:"id" db:"id"`
+	Name          string         `json:"name" db:"name"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	LastTrackedAt *time.Time     `json:"last_tracked_at,omitempty" db:"last_tracked_at"`
+	HourlyRate    *float64       `json:"hourly_rate,omitempty" db:"hourly_rate"`
+	Currency      *string        `json:"currency,omitempty" db:"currency"`
+	Budget        *time.Duration `json:"budget,omitempty" db:"budget"`
+	BudgetPeriod  *string        `json:"budget_period,omitempty" db:"budget_period"`
+	// RoundingMode, RoundingIncrement and RoundingScope override the configured rounding for the project
+	RoundingMode      *string        `json:"rounding_mode,omitempty" db:"rounding_mode"`
+	RoundingIncrement *time.Duration `json:"rounding_increment,omitempty" db:"rounding_increment"`
+	RoundingScope     *string        `json:"rounding_scope,omitempty" db:"rounding_scope"`
+}
+
+// RoundingOverride returns the rounding rule which overrides the configured rounding, or nil if the project has none
+func (p Project) RoundingOverride() *Rounding {
+	if p.RoundingMode == nil || p.RoundingIncrement == nil || p.RoundingScope == nil {
+		return nil
+	}
+
+	return &Rounding{Mode: *p.RoundingMode, Increment: *p.RoundingIncrement, Scope: *p.RoundingScope}
 }
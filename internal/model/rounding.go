@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+const (
+	RoundingModeNone    = "none"
+	RoundingModeUp      = "up"
+	RoundingModeDown    = "down"
+	RoundingModeNearest = "nearest"
+
+	// RoundingScopeEntry rounds the work time of every time entry
+	RoundingScopeEntry = "entry"
+	// RoundingScopeDay rounds the work time per project and day
+	RoundingScopeDay = "day"
+	// RoundingScopeReport rounds the work time per project over all entries of a report or export
+	RoundingScopeReport = "report"
+)
+
+// RoundingModes lists all valid rounding modes
+var RoundingModes = []string{RoundingModeNone, RoundingModeUp, RoundingModeDown, RoundingModeNearest}
+
+// RoundingScopes lists all valid rounding scopes
+var RoundingScopes = []string{RoundingScopeEntry, RoundingScopeDay, RoundingScopeReport}
+
+// Rounding defines how tracked work time is rounded to an increment for reports and exports
+type Rounding struct {
+	Mode      string        `json:"mode"`
+	Increment time.Duration `json:"increment"`
+	Scope     string        `json:"scope"`
+}
+
+// Enabled reports if the rounding changes durations at all
+func (r Rounding) Enabled() bool {
+	return r.Mode != "" && r.Mode != RoundingModeNone && r.Increment > 0
+}
+
+// Apply rounds the given duration to a multiple of the increment
+func (r Rounding) Apply(duration time.Duration) time.Duration {
+	if !r.Enabled() {
+		return duration
+	}
+
+	remainder := duration % r.Increment
+	if remainder == 0 {
+		return duration
+	}
+
+	rounded := duration - remainder
+	switch r.Mode {
+	case RoundingModeUp:
+		return rounded + r.Increment
+	case RoundingModeNearest:
+		if remainder*2 >= r.Increment {
+			return rounded + r.Increment
+		}
+	}
+
+	return rounded
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// Tag represents a tag which can be assigned to multiple time entries
+type Tag struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
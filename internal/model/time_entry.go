@@ -11,5 +11,8 @@ type TimeEntry struct {
 	EndTime   *time.Time     `json:"end_time,omitempty" db:"end_time"`
 	Duration  *time.Duration `json:"duration,omitempty" db:"duration"`
 	Category  *string        `json:"category,omitempty" db:"category"`
+	Notes     *string        `json:"notes,omitempty" db:"notes"`
+	Tags      []string       `json:"tags,omitempty" db:"-"`
+	Billable  bool           `json:"billable" db:"billable"`
 	CreatedAt time.Time      `json:"created_at" db:"created_at"`
 }
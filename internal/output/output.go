@@ -0,0 +1,173 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatMarkdown = "md"
+)
+
+// Formats lists all supported output formats
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatMarkdown}
+
+// Table holds the formatted cells of a table. The footer is optional and rendered as the last row in the formats
+// which have no dedicated footer.
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Footer []string
+}
+
+// Append appends a row to the table
+func (t *Table) Append(row []string) {
+	t.Rows = append(t.Rows, row)
+}
+
+// Validate checks if the given output format is supported
+func Validate(format string) error {
+	if !slices.Contains(Formats, format) {
+		return fmt.Errorf("invalid output format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+
+	return nil
+}
+
+// Render writes the output in the given format. JSON and YAML encode the value with the JSON field names of its
+// type, all other formats render the table. Nil slices are encoded as empty lists.
+func Render(w io.Writer, format string, value any, table *Table) error {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice && v.IsNil() {
+		value = []any{}
+	}
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case FormatYAML:
+		return renderYAML(w, value)
+	case FormatCSV:
+		return renderDelimited(w, ',', table)
+	case FormatTSV:
+		return renderDelimited(w, '\t', table)
+	case FormatMarkdown:
+		renderMarkdown(w, table)
+		return nil
+	case FormatTable:
+		renderTable(w, table)
+		return nil
+	default:
+		return Validate(format)
+	}
+}
+
+// renderYAML encodes the value as YAML. It is encoded as JSON first, so both formats share the same field names
+// and field order.
+func renderYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the order of the fields
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// resetStyle switches a decoded JSON node and its children from flow to block style
+func resetStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	// Strings keep their quotes only where YAML requires them
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.DoubleQuotedStyle {
+		node.Style = 0
+	}
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// renderDelimited writes the table as CSV with the given delimiter
+func renderDelimited(w io.Writer, delimiter rune, table *Table) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	if err := writer.WriteAll(tableRecords(table)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// renderMarkdown writes the table as a Markdown table
+func renderMarkdown(w io.Writer, table *Table) {
+	var buf bytes.Buffer
+	for i, record := range tableRecords(table) {
+		cells := make([]string, len(record))
+		for j, cell := range record {
+			cells[j] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", `\|`), "\n", " ")
+		}
+		fmt.Fprintf(&buf, "| %s |\n", strings.Join(cells, " | "))
+
+		if i == 0 {
+			fmt.Fprintf(&buf, "|%s\n", strings.Repeat(" --- |", len(record)))
+		}
+	}
+
+	_, _ = w.Write(buf.Bytes())
+}
+
+// renderTable renders the table with borders
+func renderTable(w io.Writer, table *Table) {
+	t := tablewriter.NewTable(w)
+	t.Header(table.Header)
+	for _, row := range table.Rows {
+		t.Append(row)
+	}
+	if len(table.Footer) > 0 {
+		t.Footer(table.Footer)
+	}
+	t.Render()
+}
+
+// tableRecords returns the header, rows and footer of a table
+func tableRecords(table *Table) [][]string {
+	records := make([][]string, 0, len(table.Rows)+2)
+	records = append(records, table.Header)
+	records = append(records, table.Rows...)
+	if len(table.Footer) > 0 {
+		records = append(records, table.Footer)
+	}
+
+	return records
+}
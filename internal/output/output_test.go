@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name     string        `json:"name"`
+	Code     string        `json:"code"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Tags     []string      `json:"tags,omitempty"`
+}
+
+func testTable() *Table {
+	table := &Table{Header: []string{"Name", "Notes"}, Footer: []string{"Total", "2"}}
+	table.Append([]string{"a", "with, comma"})
+	table.Append([]string{"b", "pipe | and\ttab"})
+	return table
+}
+
+func TestRender_Structured(t *testing.T) {
+	items := []testItem{{
+		Name:     "Client",
+		Code:     "0123",
+		Start:    time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC),
+		Duration: 90 * time.Minute,
+		Tags:     []string{"true", "meeting"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatJSON, items, nil))
+	assert.JSONEq(t, `[{"name":"Client","code":"0123","start":"2025-10-16T09:00:00Z","duration":5400000000000,"tags":["true","meeting"]}]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatJSON, []testItem(nil), nil))
+	assert.Equal(t, "[]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatYAML, items, nil))
+	assert.Equal(t, `- name: Client
+  code: "0123"
+  start: "2025-10-16T09:00:00Z"
+  duration: 5400000000000
+  tags:
+    - "true"
+    - meeting
+`, buf.String())
+}
+
+func TestRender_Tabular(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatCSV, nil, testTable()))
+	assert.Equal(t, "Name,Notes\na,\"with, comma\"\nb,pipe | and\ttab\nTotal,2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatTSV, nil, testTable()))
+	assert.Equal(t, "Name\tNotes\na\twith, comma\nb\t\"pipe | and\ttab\"\nTotal\t2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatMarkdown, nil, testTable()))
+	assert.Equal(t, "| Name | Notes |\n| --- | --- |\n| a | with, comma |\n| b | pipe \\| and\ttab |\n| Total | 2 |\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatTable, nil, testTable()))
+	assert.Contains(t, buf.String(), "with, comma")
+}
+
+func TestValidate(t *testing.T) {
+	for _, format := range Formats {
+		assert.NoError(t, Validate(format))
+	}
+
+	assert.Error(t, Validate("xml"))
+	assert.Error(t, Render(&bytes.Buffer{}, "xml", nil, testTable()))
+}
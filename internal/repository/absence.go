@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+const (
+	absenceTable = "absences"
+	// absenceDateLayout is the layout of the stored absence dates
+	absenceDateLayout = "2006-01-02"
+)
+
+// Absence defines the interface for absence data operations
+type Absence interface {
+	// Create creates a new absence for the given day
+	Create(ctx context.Context, date time.Time, absenceType string, halfDay bool, note *string) (*model.Absence, error)
+	// Insert inserts an absence with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+	Insert(ctx context.Context, absence model.Absence) (int, error)
+	// GetByID retrieves an absence by its ID
+	GetByID(ctx context.Context, id int) (*model.Absence, error)
+	// GetInRange retrieves all absences between the given days (both inclusive and optional) ordered by date
+	GetInRange(ctx context.Context, from *time.Time, until *time.Time) ([]model.Absence, error)
+	// DeleteByID deletes an absence by ID
+	DeleteByID(ctx context.Context, id int) error
+	// DeleteAll deletes all absences
+	DeleteAll(ctx context.Context) error
+}
+
+type absence struct {
+	db dbtx
+}
+
+// NewAbsence creates a new absence repository
+func NewAbsence(db *sql.DB) Absence {
+	return &absence{db: db}
+}
+
+// Create creates a new absence for the given day
+func (r *absence) Create(ctx context.Context, date time.Time, absenceType string, halfDay bool, note *string) (*model.Absence, error) {
+	query, args, err := goqu.Insert(absenceTable).Rows(goqu.Record{
+		"date":     formatAbsenceDate(date),
+		"type":     absenceType,
+		"half_day": halfDay,
+		"note":     note,
+	}).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, int(id))
+}
+
+// Insert inserts an absence with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+func (r *absence) Insert(ctx context.Context, absence model.Absence) (int, error) {
+	record := goqu.Record{
+		"date":       formatAbsenceDate(absence.Date),
+		"type":       absence.Type,
+		"half_day":   absence.HalfDay,
+		"note":       absence.Note,
+		"created_at": absence.CreatedAt,
+	}
+
+	if absence.ID != 0 {
+		record["id"] = absence.ID
+	}
+
+	return insertRecord(ctx, r.db, absenceTable, record)
+}
+
+// GetByID retrieves an absence by its ID
+func (r *absence) GetByID(ctx context.Context, id int) (*model.Absence, error) {
+	query, args, err := goqu.From(absenceTable).
+		Select("id", "date", "type", "half_day", "note", "created_at").
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAbsence(r.db.QueryRowContext(ctx, query, args...))
+}
+
+// GetInRange retrieves all absences between the given days (both inclusive and optional) ordered by date
+func (r *absence) GetInRange(ctx context.Context, from *time.Time, until *time.Time) ([]model.Absence, error) {
+	queryBuilder := goqu.From(absenceTable).
+		Select("id", "date", "type", "half_day", "note", "created_at").
+		Order(goqu.C("date").Asc(), goqu.C("id").Asc())
+
+	if from != nil {
+		queryBuilder = queryBuilder.Where(goqu.C("date").Gte(formatAbsenceDate(*from)))
+	}
+
+	if until != nil {
+		queryBuilder = queryBuilder.Where(goqu.C("date").Lte(formatAbsenceDate(*until)))
+	}
+
+	query, args, err := queryBuilder.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var absences []model.Absence
+	for rows.Next() {
+		absence, err := scanAbsence(rows)
+		if err != nil {
+			return nil, err
+		}
+		absences = append(absences, *absence)
+	}
+
+	return absences, rows.Err()
+}
+
+// DeleteByID deletes an absence by ID
+func (r *absence) DeleteByID(ctx context.Context, id int) error {
+	query, args, err := goqu.Delete(absenceTable).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteAll deletes all absences
+func (r *absence) DeleteAll(ctx context.Context) error {
+	query, args, err := goqu.Delete(absenceTable).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAbsence scans an absence row and parses its date in the local timezone
+func scanAbsence(row rowScanner) (*model.Absence, error) {
+	var (
+		absence model.Absence
+		date    string
+	)
+
+	err := row.Scan(
+		&absence.ID,
+		&date,
+		&absence.Type,
+		&absence.HalfDay,
+		&absence.Note,
+		&absence.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	absence.Date, err = time.ParseInLocation(absenceDateLayout, date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &absence, nil
+}
+
+// formatAbsenceDate formats the local calendar day of the given time
+func formatAbsenceDate(date time.Time) string {
+	return date.Local().Format(absenceDateLayout)
+}
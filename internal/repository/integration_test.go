@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/nitschmann/hora/internal/config"
 	"github.com/nitschmann/hora/internal/database"
+	"github.com/nitschmann/hora/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -85,7 +87,7 @@ func TestTimeEntryIntegration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test Create time entry
-	timeEntry, err := timeEntryRepo.Create(ctx, project.ID, time.Now(), nil)
+	timeEntry, err := timeEntryRepo.Create(ctx, project.ID, time.Now(), nil, nil, true)
 	require.NoError(t, err)
 	assert.Equal(t, project.ID, timeEntry.ProjectID)
 	assert.NotZero(t, timeEntry.ID)
@@ -108,6 +110,118 @@ func TestTimeEntryIntegration(t *testing.T) {
 	entries, err := timeEntryRepo.GetByProject(ctx, project.ID, 10, "desc")
 	require.NoError(t, err)
 	assert.Len(t, entries, 1)
+
+	// Test Update
+	otherProject, err := projectRepo.Create(ctx, "Test Project Time Other")
+	require.NoError(t, err)
+
+	category := "development"
+	found, err = timeEntryRepo.GetByID(ctx, timeEntry.ID)
+	require.NoError(t, err)
+	found.ProjectID = otherProject.ID
+	found.Category = &category
+	err = timeEntryRepo.Update(ctx, found)
+	require.NoError(t, err)
+
+	updated, err := timeEntryRepo.GetByID(ctx, timeEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, otherProject.ID, updated.ProjectID)
+	assert.Equal(t, "development", *updated.Category)
+	assert.Equal(t, time.Hour, *updated.Duration)
+	assert.Nil(t, updated.Notes)
+
+	// Test UpdateNotes
+	notes := "Reviewed PR"
+	err = timeEntryRepo.UpdateNotes(ctx, timeEntry.ID, &notes)
+	require.NoError(t, err)
+
+	entries, err = timeEntryRepo.GetByProject(ctx, otherProject.ID, 10, "desc")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Reviewed PR", *entries[0].Notes)
+}
+
+func TestTimeEntryCreateCompletedIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	pauseRepo := NewPause(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Test Project Completed")
+	require.NoError(t, err)
+
+	startTime := time.Now().Add(-3 * time.Hour)
+	endTime := time.Now().Add(-time.Hour)
+
+	// Test CreateCompleted time entry
+	timeEntry, err := timeEntryRepo.CreateCompleted(ctx, project.ID, startTime, endTime, 90*time.Minute, nil, nil, true)
+	require.NoError(t, err)
+	require.NotNil(t, timeEntry.EndTime)
+	require.NotNil(t, timeEntry.Duration)
+	assert.Equal(t, 90*time.Minute, *timeEntry.Duration)
+
+	// Completed entries must not be active
+	_, err = timeEntryRepo.GetActive(ctx)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Test CreateCompleted pause
+	pause, err := pauseRepo.CreateCompleted(ctx, timeEntry.ID, startTime.Add(time.Hour), startTime.Add(90*time.Minute), 30*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, pause.Duration)
+	assert.Equal(t, 30*time.Minute, *pause.Duration)
+
+	_, err = pauseRepo.GetActivePause(ctx, timeEntry.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Test GetOverlapping
+	overlapping, err := timeEntryRepo.GetOverlapping(ctx, endTime.Add(-time.Minute), endTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, overlapping, 1)
+
+	overlapping, err = timeEntryRepo.GetOverlapping(ctx, endTime, endTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, overlapping)
+}
+
+func TestTransactorIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+
+	// Test commit
+	err := transactor.WithinTransaction(ctx, func(repos Repositories) error {
+		_, err := repos.Project.Create(ctx, "Test Project Committed")
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = projectRepo.GetByName(ctx, "Test Project Committed")
+	assert.NoError(t, err)
+
+	// Test rollback
+	err = transactor.WithinTransaction(ctx, func(repos Repositories) error {
+		project, err := repos.Project.Create(ctx, "Test Project Rolled Back")
+		if err != nil {
+			return err
+		}
+
+		_, err = repos.TimeEntry.Create(ctx, project.ID, time.Now(), nil, nil, true)
+		if err != nil {
+			return err
+		}
+
+		return errors.New("abort")
+	})
+	assert.EqualError(t, err, "abort")
+
+	_, err = projectRepo.GetByName(ctx, "Test Project Rolled Back")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
 }
 
 func TestPauseIntegration(t *testing.T) {
@@ -123,7 +237,7 @@ func TestPauseIntegration(t *testing.T) {
 	project, err := projectRepo.Create(ctx, "Test Project Pause")
 	require.NoError(t, err)
 
-	timeEntry, err := timeEntryRepo.Create(ctx, project.ID, time.Now(), nil)
+	timeEntry, err := timeEntryRepo.Create(ctx, project.ID, time.Now(), nil, nil, true)
 	require.NoError(t, err)
 
 	// Test Create pause
@@ -150,6 +264,19 @@ func TestPauseIntegration(t *testing.T) {
 	pauses, err := pauseRepo.GetByTimeEntry(ctx, timeEntry.ID)
 	require.NoError(t, err)
 	assert.Len(t, pauses, 1)
+
+	// Test DeleteByID
+	err = pauseRepo.DeleteByID(ctx, pause.ID)
+	require.NoError(t, err)
+
+	_, err = pauseRepo.GetByID(ctx, pause.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = timeEntryRepo.DeleteByID(ctx, timeEntry.ID)
+	require.NoError(t, err)
+
+	_, err = timeEntryRepo.GetByID(ctx, timeEntry.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
 }
 
 func TestTimeEntryCategoriesIntegration(t *testing.T) {
@@ -174,21 +301,21 @@ func TestTimeEntryCategoriesIntegration(t *testing.T) {
 	category2 := "testing"
 	category3 := "meeting"
 
-	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category1)
+	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category1, nil, true)
 	require.NoError(t, err)
 
-	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category2)
+	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category2, nil, true)
 	require.NoError(t, err)
 
-	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category3)
+	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category3, nil, true)
 	require.NoError(t, err)
 
 	// Create entry with same category as first one
-	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category1)
+	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), &category1, nil, true)
 	require.NoError(t, err)
 
 	// Create entry without category
-	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), nil)
+	_, err = timeEntryRepo.Create(ctx, project.ID, time.Now(), nil, nil, true)
 	require.NoError(t, err)
 
 	// Test GetCategories
@@ -200,3 +327,445 @@ func TestTimeEntryCategoriesIntegration(t *testing.T) {
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 3)
 }
+
+func TestTagIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	tagRepo := NewTag(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Test Project Tags")
+	require.NoError(t, err)
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	first, err := timeEntryRepo.CreateCompleted(ctx, project.ID, startTime, startTime.Add(time.Hour), time.Hour, nil, nil, true)
+	require.NoError(t, err)
+	second, err := timeEntryRepo.CreateCompleted(ctx, project.ID, startTime.Add(2*time.Hour), startTime.Add(3*time.Hour), time.Hour, nil, nil, true)
+	require.NoError(t, err)
+
+	// Test SetForTimeEntry
+	require.NoError(t, tagRepo.SetForTimeEntry(ctx, first.ID, []string{"meeting", "client-x"}))
+	require.NoError(t, tagRepo.SetForTimeEntry(ctx, second.ID, []string{"client-x"}))
+
+	found, err := timeEntryRepo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-x", "meeting"}, found.Tags)
+
+	// Test filtering by any of the tags
+	entries, err := timeEntryRepo.GetAllWithPausesFiltered(ctx, 10, "asc", TimeEntryFilter{Tags: []string{"meeting", "client-x"}})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// Test filtering by all of the tags
+	entries, err = timeEntryRepo.GetAllWithPausesFiltered(ctx, 10, "asc", TimeEntryFilter{Tags: []string{"meeting", "client-x"}, MatchAllTags: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, first.ID, entries[0].ID)
+	assert.Equal(t, []string{"client-x", "meeting"}, entries[0].Tags)
+
+	// Test GetAllWithUsage
+	tags, err := tagRepo.GetAllWithUsage(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "client-x", tags[0].Name)
+	assert.Equal(t, 2, tags[0].UsageCount)
+	assert.Equal(t, "meeting", tags[1].Name)
+	assert.Equal(t, 1, tags[1].UsageCount)
+
+	// Test replacing tags
+	require.NoError(t, tagRepo.SetForTimeEntry(ctx, first.ID, []string{"remote"}))
+	found, err = timeEntryRepo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"remote"}, found.Tags)
+
+	// Test DeleteByTimeEntry
+	require.NoError(t, tagRepo.DeleteByTimeEntry(ctx, first.ID))
+	found, err = timeEntryRepo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.Tags)
+}
+
+func TestDeleteByProjectIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	pauseRepo := NewPause(db)
+	tagRepo := NewTag(db)
+	ctx := context.Background()
+
+	removed, err := projectRepo.Create(ctx, "Removed Project")
+	require.NoError(t, err)
+	kept, err := projectRepo.Create(ctx, "Kept Project")
+	require.NoError(t, err)
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	var entryIDs []int
+	for i, project := range []*model.Project{removed, kept} {
+		start := startTime.Add(time.Duration(i) * 2 * time.Hour)
+		entry, err := timeEntryRepo.CreateCompleted(ctx, project.ID, start, start.Add(time.Hour), 45*time.Minute, nil, nil, true)
+		require.NoError(t, err)
+		_, err = pauseRepo.CreateCompleted(ctx, entry.ID, start.Add(10*time.Minute), start.Add(25*time.Minute), 15*time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, tagRepo.SetForTimeEntry(ctx, entry.ID, []string{"meeting"}))
+		entryIDs = append(entryIDs, entry.ID)
+	}
+
+	require.NoError(t, pauseRepo.DeleteByProject(ctx, removed.ID))
+	require.NoError(t, tagRepo.DeleteByProject(ctx, removed.ID))
+
+	// Only the pauses and tags of the entries of the project are deleted
+	pauses, err := pauseRepo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, pauses, 1)
+	assert.Equal(t, entryIDs[1], pauses[0].TimeEntryID)
+
+	tags, err := tagRepo.GetAllWithUsage(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, 1, tags[0].UsageCount)
+}
+
+func TestBillingIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Billing Project")
+	require.NoError(t, err)
+	assert.Nil(t, project.HourlyRate)
+	assert.Nil(t, project.Currency)
+
+	rate := 85.5
+	currency := "USD"
+	require.NoError(t, projectRepo.UpdateRate(ctx, project.ID, &rate, &currency))
+
+	found, err := projectRepo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.HourlyRate)
+	assert.Equal(t, 85.5, *found.HourlyRate)
+	assert.Equal(t, "USD", *found.Currency)
+
+	// Setting a category rate twice updates it
+	require.NoError(t, projectRepo.SetCategoryRate(ctx, project.ID, "meeting", 60))
+	require.NoError(t, projectRepo.SetCategoryRate(ctx, project.ID, "meeting", 70))
+
+	categoryRates, err := projectRepo.GetCategoryRates(ctx)
+	require.NoError(t, err)
+	require.Len(t, categoryRates, 1)
+	assert.Equal(t, 70.0, categoryRates[0].HourlyRate)
+
+	require.NoError(t, projectRepo.DeleteCategoryRate(ctx, project.ID, "meeting"))
+	categoryRates, err = projectRepo.GetCategoryRates(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, categoryRates)
+
+	require.NoError(t, projectRepo.UpdateRate(ctx, project.ID, nil, nil))
+	found, err = projectRepo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found.HourlyRate)
+	assert.Nil(t, found.Currency)
+
+	startTime := time.Now().Add(-3 * time.Hour)
+	entry, err := timeEntryRepo.CreateCompleted(ctx, project.ID, startTime, startTime.Add(time.Hour), time.Hour, nil, nil, false)
+	require.NoError(t, err)
+
+	foundEntry, err := timeEntryRepo.GetByID(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.False(t, foundEntry.Billable)
+
+	foundEntry.Billable = true
+	require.NoError(t, timeEntryRepo.Update(ctx, foundEntry))
+
+	entries, err := timeEntryRepo.GetAllWithPauses(ctx, 10, "desc", nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].Billable)
+}
+
+func TestBudgetIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Budget Project")
+	require.NoError(t, err)
+	assert.Nil(t, project.Budget)
+
+	budget := 40 * time.Hour
+	period := "month"
+	require.NoError(t, projectRepo.UpdateBudget(ctx, project.ID, &budget, &period))
+
+	projects, err := projectRepo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	require.NotNil(t, projects[0].Budget)
+	assert.Equal(t, budget, *projects[0].Budget)
+	assert.Equal(t, "month", *projects[0].BudgetPeriod)
+
+	startTime := time.Now().Add(-5 * time.Hour)
+	_, err = timeEntryRepo.CreateCompleted(ctx, project.ID, startTime, startTime.Add(2*time.Hour), 90*time.Minute, nil, nil, true)
+	require.NoError(t, err)
+	_, err = timeEntryRepo.CreateCompleted(ctx, project.ID, startTime.Add(3*time.Hour), startTime.Add(4*time.Hour), time.Hour, nil, nil, true)
+	require.NoError(t, err)
+	// Running entries are not included
+	_, err = timeEntryRepo.Create(ctx, project.ID, startTime.Add(4*time.Hour), nil, nil, true)
+	require.NoError(t, err)
+
+	workTime, err := timeEntryRepo.GetWorkTimeByProject(ctx, project.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 150*time.Minute, workTime)
+
+	since := startTime.Add(time.Hour)
+	workTime, err = timeEntryRepo.GetWorkTimeByProject(ctx, project.ID, &since)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, workTime)
+
+	require.NoError(t, projectRepo.UpdateBudget(ctx, project.ID, nil, nil))
+	found, err := projectRepo.GetByName(ctx, "Budget Project")
+	require.NoError(t, err)
+	assert.Nil(t, found.Budget)
+	assert.Nil(t, found.BudgetPeriod)
+}
+
+func TestAbsenceIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewAbsence(db)
+	ctx := context.Background()
+
+	day := time.Date(2025, 12, 24, 0, 0, 0, 0, time.Local)
+	note := "Christmas Eve"
+
+	absence, err := repo.Create(ctx, day.Add(15*time.Hour), "holiday", true, &note)
+	require.NoError(t, err)
+	assert.Equal(t, day, absence.Date)
+	assert.Equal(t, "holiday", absence.Type)
+	assert.True(t, absence.HalfDay)
+	require.NotNil(t, absence.Note)
+	assert.Equal(t, note, *absence.Note)
+
+	_, err = repo.Create(ctx, day.AddDate(0, 0, 1), "holiday", false, nil)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, day.AddDate(0, 0, 7), "vacation", false, nil)
+	require.NoError(t, err)
+
+	absences, err := repo.GetInRange(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, absences, 3)
+
+	// Both days of the range are inclusive
+	from := day
+	until := day.AddDate(0, 0, 1)
+	absences, err = repo.GetInRange(ctx, &from, &until)
+	require.NoError(t, err)
+	require.Len(t, absences, 2)
+	assert.Equal(t, absence.ID, absences[0].ID)
+	assert.Nil(t, absences[1].Note)
+
+	err = repo.DeleteByID(ctx, absence.ID)
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, absence.ID)
+	assert.Error(t, err)
+
+	err = repo.DeleteAll(ctx)
+	require.NoError(t, err)
+
+	absences, err = repo.GetInRange(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, absences)
+}
+
+func TestRoundingIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewProject(db)
+	ctx := context.Background()
+
+	project, err := repo.Create(ctx, "Rounding Project")
+	require.NoError(t, err)
+	assert.Nil(t, project.RoundingOverride())
+
+	rounding := model.Rounding{Mode: "up", Increment: 6 * time.Minute, Scope: "day"}
+	require.NoError(t, repo.UpdateRounding(ctx, project.ID, &rounding))
+
+	project, err = repo.GetByName(ctx, "Rounding Project")
+	require.NoError(t, err)
+	assert.Equal(t, &rounding, project.RoundingOverride())
+
+	projects, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, &rounding, projects[0].RoundingOverride())
+
+	require.NoError(t, repo.UpdateRounding(ctx, project.ID, nil))
+
+	project, err = repo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Nil(t, project.RoundingOverride())
+}
+
+func TestDateFilterIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Date Filter Project")
+	require.NoError(t, err)
+
+	day := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		start := day.AddDate(0, 0, i)
+		_, err := timeEntryRepo.CreateCompleted(ctx, project.ID, start, start.Add(time.Hour), time.Hour, nil, nil, true)
+		require.NoError(t, err)
+	}
+
+	since := day.Truncate(24 * time.Hour)
+	until := since.AddDate(0, 0, 2)
+
+	entries, err := timeEntryRepo.GetAllWithPausesFiltered(ctx, 10, "asc", TimeEntryFilter{Since: &since, Until: &until})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[1].StartTime.Before(until))
+
+	entries, err = timeEntryRepo.GetByProjectIDOrNameWithPauses(ctx, "Date Filter Project", 10, "asc", nil, &until)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	total, err := timeEntryRepo.GetTotalTimeByProjectIDOrName(ctx, "Date Filter Project", &since, &until)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, total)
+}
+
+func TestDateFilterIntegration_LocalTimeZone(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	t.Cleanup(func() {
+		time.Local = local
+	})
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	ctx := context.Background()
+
+	project, err := projectRepo.Create(ctx, "Time Zone Project")
+	require.NoError(t, err)
+
+	// 01:00 local time on the 17th is still the 16th in UTC
+	day := time.Date(2025, 10, 17, 0, 0, 0, 0, time.Local)
+	start := day.Add(time.Hour)
+	_, err = timeEntryRepo.CreateCompleted(ctx, project.ID, start, start.Add(time.Hour), time.Hour, nil, nil, true)
+	require.NoError(t, err)
+
+	entries, err := timeEntryRepo.GetAllWithPausesFiltered(ctx, 10, "asc", TimeEntryFilter{Since: &day})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = timeEntryRepo.GetAllWithPausesFiltered(ctx, 10, "asc", TimeEntryFilter{Until: &day})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = timeEntryRepo.GetByProjectIDOrNameWithPauses(ctx, "Time Zone Project", 10, "asc", &day, nil)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestInsertIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := NewProject(db)
+	timeEntryRepo := NewTimeEntry(db)
+	pauseRepo := NewPause(db)
+	absenceRepo := NewAbsence(db)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	budget := 10 * time.Hour
+	period := "month"
+
+	// Records keep the given IDs and creation times
+	projectID, err := projectRepo.Insert(ctx, model.Project{ID: 42, Name: "Restored", CreatedAt: createdAt, Budget: &budget, BudgetPeriod: &period})
+	require.NoError(t, err)
+	assert.Equal(t, 42, projectID)
+
+	project, err := projectRepo.GetByID(ctx, projectID)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(project.CreatedAt))
+	require.NotNil(t, project.Budget)
+	assert.Equal(t, budget, *project.Budget)
+
+	start := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	duration := 105 * time.Minute
+	entryID, err := timeEntryRepo.Insert(ctx, model.TimeEntry{ID: 7, ProjectID: projectID, StartTime: start, EndTime: &end, Duration: &duration, Billable: true, CreatedAt: createdAt})
+	require.NoError(t, err)
+	assert.Equal(t, 7, entryID)
+
+	entry, err := timeEntryRepo.GetByProjectAndStartTime(ctx, projectID, start)
+	require.NoError(t, err)
+	assert.Equal(t, entryID, entry.ID)
+	require.NotNil(t, entry.Duration)
+	assert.Equal(t, duration, *entry.Duration)
+
+	_, err = timeEntryRepo.GetByProjectAndStartTime(ctx, projectID, end)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+	// Tracked entries are stored with fractions of a second, which exported and imported times do not carry
+	tracked := time.Date(2025, 10, 17, 0, 45, 41, 368104656, time.UTC)
+	trackedEntry, err := timeEntryRepo.Create(ctx, projectID, tracked, nil, nil, true)
+	require.NoError(t, err)
+
+	entry, err = timeEntryRepo.GetByProjectAndStartTime(ctx, projectID, tracked.Truncate(time.Second).Local())
+	require.NoError(t, err)
+	assert.Equal(t, trackedEntry.ID, entry.ID)
+
+	_, err = timeEntryRepo.GetByProjectAndStartTime(ctx, projectID, tracked.Truncate(time.Second).Add(time.Second))
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+	pauseStart := start.Add(time.Hour)
+	pauseEnd := pauseStart.Add(15 * time.Minute)
+	pauseDuration := 15 * time.Minute
+	_, err = pauseRepo.Insert(ctx, model.Pause{ID: 3, TimeEntryID: entryID, PauseStart: pauseStart, PauseEnd: &pauseEnd, Duration: &pauseDuration, CreatedAt: createdAt})
+	require.NoError(t, err)
+
+	pauses, err := pauseRepo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, pauses, 1)
+	assert.Equal(t, 3, pauses[0].ID)
+	assert.Equal(t, entryID, pauses[0].TimeEntryID)
+
+	// A zero ID is assigned by the database
+	absenceID, err := absenceRepo.Insert(ctx, model.Absence{Date: time.Date(2025, 12, 24, 0, 0, 0, 0, time.Local), Type: "holiday", CreatedAt: createdAt})
+	require.NoError(t, err)
+	assert.NotZero(t, absenceID)
+
+	// Deleting all projects cascades to their time entries and pauses
+	require.NoError(t, projectRepo.DeleteAll(ctx))
+
+	pauses, err = pauseRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pauses)
+
+	_, err = timeEntryRepo.GetByID(ctx, entryID)
+	assert.Error(t, err)
+}
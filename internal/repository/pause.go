@@ -16,6 +16,12 @@ const pauseTable = "pauses"
 type Pause interface {
 	// Create creates a new pause
 	Create(ctx context.Context, timeEntryID int, pauseStart time.Time) (*model.Pause, error)
+	// CreateCompleted creates a new pause which already has an end time and duration
+	CreateCompleted(ctx context.Context, timeEntryID int, pauseStart time.Time, pauseEnd time.Time, duration time.Duration) (*model.Pause, error)
+	// Insert inserts a pause with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+	Insert(ctx context.Context, pause model.Pause) (int, error)
+	// GetAll retrieves all pauses ordered by their start time
+	GetAll(ctx context.Context) ([]model.Pause, error)
 	// GetByID retrieves a pause by its ID
 	GetByID(ctx context.Context, id int) (*model.Pause, error)
 	// GetActivePause retrieves the currently active pause for a time entry
@@ -24,14 +30,18 @@ type Pause interface {
 	EndPause(ctx context.Context, id int, pauseEnd time.Time, duration time.Duration) error
 	// GetByTimeEntry retrieves all pauses for a specific time entry
 	GetByTimeEntry(ctx context.Context, timeEntryID int) ([]model.Pause, error)
+	// DeleteByID deletes a pause by its ID
+	DeleteByID(ctx context.Context, id int) error
 	// DeleteByTimeEntry deletes all pauses for a specific time entry
 	DeleteByTimeEntry(ctx context.Context, timeEntryID int) error
+	// DeleteByProject deletes all pauses of the time entries of a specific project
+	DeleteByProject(ctx context.Context, projectID int) error
 	// DeleteAll deletes all pauses
 	DeleteAll(ctx context.Context) error
 }
 
 type pause struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewPause creates a new pause repository
@@ -62,6 +72,31 @@ func (r *pause) Create(ctx context.Context, timeEntryID int, pauseStart time.Tim
 	return r.GetByID(ctx, int(id))
 }
 
+// CreateCompleted creates a new pause which already has an end time and duration
+func (r *pause) CreateCompleted(ctx context.Context, timeEntryID int, pauseStart time.Time, pauseEnd time.Time, duration time.Duration) (*model.Pause, error) {
+	query, args, err := goqu.Insert(pauseTable).Rows(goqu.Record{
+		"time_entry_id": timeEntryID,
+		"pause_start":   pauseStart,
+		"pause_end":     pauseEnd,
+		"duration":      int64(duration.Seconds()),
+	}).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, int(id))
+}
+
 // GetByID retrieves a pause by its ID
 func (r *pause) GetByID(ctx context.Context, id int) (*model.Pause, error) {
 	query, args, err := goqu.From(pauseTable).
@@ -171,6 +206,18 @@ func (r *pause) GetByTimeEntry(ctx context.Context, timeEntryID int) ([]model.Pa
 	return r.scanPauses(rows)
 }
 
+// DeleteByID deletes a pause by its ID
+func (r *pause) DeleteByID(ctx context.Context, id int) error {
+	query, args, err := goqu.Delete(pauseTable).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 // DeleteByTimeEntry deletes all pauses for a specific time entry
 func (r *pause) DeleteByTimeEntry(ctx context.Context, timeEntryID int) error {
 	query, args, err := goqu.Delete(pauseTable).
@@ -183,6 +230,60 @@ func (r *pause) DeleteByTimeEntry(ctx context.Context, timeEntryID int) error {
 	return err
 }
 
+// DeleteByProject deletes all pauses of the time entries of a specific project
+func (r *pause) DeleteByProject(ctx context.Context, projectID int) error {
+	query, args, err := goqu.Delete(pauseTable).
+		Where(goqu.C("time_entry_id").In(
+			goqu.From(timeEntryTable).Select("id").Where(goqu.C("project_id").Eq(projectID)),
+		)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Insert inserts a pause with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+func (r *pause) Insert(ctx context.Context, pause model.Pause) (int, error) {
+	record := goqu.Record{
+		"time_entry_id": pause.TimeEntryID,
+		"pause_start":   pause.PauseStart,
+		"pause_end":     pause.PauseEnd,
+		"duration":      nil,
+		"created_at":    pause.CreatedAt,
+	}
+
+	if pause.ID != 0 {
+		record["id"] = pause.ID
+	}
+
+	if pause.Duration != nil {
+		record["duration"] = int64(pause.Duration.Seconds())
+	}
+
+	return insertRecord(ctx, r.db, pauseTable, record)
+}
+
+// GetAll retrieves all pauses ordered by their start time
+func (r *pause) GetAll(ctx context.Context) ([]model.Pause, error) {
+	query, args, err := goqu.From(pauseTable).
+		Select("id", "time_entry_id", "pause_start", "pause_end", "duration", "created_at").
+		Order(goqu.C("pause_start").Asc(), goqu.C("id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPauses(rows)
+}
+
 // DeleteAll deletes all pauses
 func (r *pause) DeleteAll(ctx context.Context) error {
 	query, args, err := goqu.Delete(pauseTable).ToSQL()
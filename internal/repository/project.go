@@ -11,12 +11,17 @@ import (
 	"github.com/nitschmann/hora/internal/model"
 )
 
-const projectTable = "projects"
+const (
+	projectTable      = "projects"
+	categoryRateTable = "category_rates"
+)
 
 // Project defines the interface for project data operations
 type Project interface {
 	// Create creates a new project
 	Create(ctx context.Context, name string) (*model.Project, error)
+	// Insert inserts a project with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+	Insert(ctx context.Context, project model.Project) (int, error)
 	// GetByID retrieves a project by its ID
 	GetByID(ctx context.Context, id int) (*model.Project, error)
 	// GetByName retrieves a project by its name
@@ -29,12 +34,26 @@ type Project interface {
 	Delete(ctx context.Context, name string) error
 	// DeleteByID deletes a project by ID
 	DeleteByID(ctx context.Context, id int) error
+	// DeleteAll deletes all projects, which deletes their time entries, pauses and category rates as well
+	DeleteAll(ctx context.Context) error
 	// GetByIDOrName retrieves a project by ID (if numeric) or name
 	GetByIDOrName(ctx context.Context, idOrName string) (*model.Project, error)
+	// UpdateRate updates the hourly rate and currency of a project
+	UpdateRate(ctx context.Context, id int, hourlyRate *float64, currency *string) error
+	// SetCategoryRate sets the hourly rate which overrides the project rate for a category
+	SetCategoryRate(ctx context.Context, projectID int, category string, hourlyRate float64) error
+	// DeleteCategoryRate deletes the hourly rate of a category within a project
+	DeleteCategoryRate(ctx context.Context, projectID int, category string) error
+	// GetCategoryRates retrieves all category rates
+	GetCategoryRates(ctx context.Context) ([]model.CategoryRate, error)
+	// UpdateBudget updates the time budget and budget period of a project
+	UpdateBudget(ctx context.Context, id int, budget *time.Duration, period *string) error
+	// UpdateRounding updates the rounding rule which overrides the configured rounding for a project
+	UpdateRounding(ctx context.Context, id int, rounding *model.Rounding) error
 }
 
 type project struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewProject creates a new project repository
@@ -65,49 +84,106 @@ func (r *project) Create(ctx context.Context, name string) (*model.Project, erro
 	return r.GetByID(ctx, int(id))
 }
 
+// Insert inserts a project with all its fields, e.g. from a backup. A zero ID is assigned by the database.
+func (r *project) Insert(ctx context.Context, project model.Project) (int, error) {
+	record := goqu.Record{
+		"name":               project.Name,
+		"created_at":         project.CreatedAt,
+		"hourly_rate":        project.HourlyRate,
+		"currency":           project.Currency,
+		"budget":             nil,
+		"budget_period":      project.BudgetPeriod,
+		"rounding_mode":      project.RoundingMode,
+		"rounding_increment": nil,
+		"rounding_scope":     project.RoundingScope,
+	}
+
+	if project.ID != 0 {
+		record["id"] = project.ID
+	}
+
+	if project.Budget != nil {
+		record["budget"] = int64(project.Budget.Seconds())
+	}
+
+	if project.RoundingIncrement != nil {
+		record["rounding_increment"] = int64(project.RoundingIncrement.Seconds())
+	}
+
+	return insertRecord(ctx, r.db, projectTable, record)
+}
+
 // GetByID retrieves a project by its ID
 func (r *project) GetByID(ctx context.Context, id int) (*model.Project, error) {
 	query, args, err := goqu.From(projectTable).
-		Select("id", "name", "created_at").
+		Select("id", "name", "created_at", "hourly_rate", "currency", "budget", "budget_period", "rounding_mode", "rounding_increment", "rounding_scope").
 		Where(goqu.C("id").Eq(id)).
 		ToSQL()
 	if err != nil {
 		return nil, err
 	}
 
-	var project model.Project
+	var (
+		project                  model.Project
+		budgetSeconds            *int64
+		roundingIncrementSeconds *int64
+	)
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(
 		&project.ID,
 		&project.Name,
 		&project.CreatedAt,
+		&project.HourlyRate,
+		&project.Currency,
+		&budgetSeconds,
+		&project.BudgetPeriod,
+		&project.RoundingMode,
+		&roundingIncrementSeconds,
+		&project.RoundingScope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	setProjectBudget(&project, budgetSeconds)
+	setProjectRoundingIncrement(&project, roundingIncrementSeconds)
+
 	return &project, nil
 }
 
 // GetByName retrieves a project by its name
 func (r *project) GetByName(ctx context.Context, name string) (*model.Project, error) {
 	query, args, err := goqu.From(projectTable).
-		Select("id", "name", "created_at").
+		Select("id", "name", "created_at", "hourly_rate", "currency", "budget", "budget_period", "rounding_mode", "rounding_increment", "rounding_scope").
 		Where(goqu.C("name").Eq(name)).
 		ToSQL()
 	if err != nil {
 		return nil, err
 	}
 
-	var project model.Project
+	var (
+		project                  model.Project
+		budgetSeconds            *int64
+		roundingIncrementSeconds *int64
+	)
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(
 		&project.ID,
 		&project.Name,
 		&project.CreatedAt,
+		&project.HourlyRate,
+		&project.Currency,
+		&budgetSeconds,
+		&project.BudgetPeriod,
+		&project.RoundingMode,
+		&roundingIncrementSeconds,
+		&project.RoundingScope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	setProjectBudget(&project, budgetSeconds)
+	setProjectRoundingIncrement(&project, roundingIncrementSeconds)
+
 	return &project, nil
 }
 
@@ -132,8 +208,26 @@ func (r *project) GetAll(ctx context.Context) ([]model.Project, error) {
 			goqu.I("projects.name"),
 			goqu.I("projects.created_at"),
 			goqu.MAX(goqu.I("time_entries.end_time")).As("last_tracked_at"),
+			goqu.I("projects.hourly_rate"),
+			goqu.I("projects.currency"),
+			goqu.I("projects.budget"),
+			goqu.I("projects.budget_period"),
+			goqu.I("projects.rounding_mode"),
+			goqu.I("projects.rounding_increment"),
+			goqu.I("projects.rounding_scope"),
+		).
+		GroupBy(
+			goqu.I("projects.id"),
+			goqu.I("projects.name"),
+			goqu.I("projects.created_at"),
+			goqu.I("projects.hourly_rate"),
+			goqu.I("projects.currency"),
+			goqu.I("projects.budget"),
+			goqu.I("projects.budget_period"),
+			goqu.I("projects.rounding_mode"),
+			goqu.I("projects.rounding_increment"),
+			goqu.I("projects.rounding_scope"),
 		).
-		GroupBy(goqu.I("projects.id"), goqu.I("projects.name"), goqu.I("projects.created_at")).
 		Order(goqu.I("projects.name").Asc()).
 		ToSQL()
 	if err != nil {
@@ -150,17 +244,29 @@ func (r *project) GetAll(ctx context.Context) ([]model.Project, error) {
 	for rows.Next() {
 		var project model.Project
 		var lastTrackedAtStr *string
+		var budgetSeconds *int64
+		var roundingIncrementSeconds *int64
 
 		err := rows.Scan(
 			&project.ID,
 			&project.Name,
 			&project.CreatedAt,
 			&lastTrackedAtStr,
+			&project.HourlyRate,
+			&project.Currency,
+			&budgetSeconds,
+			&project.BudgetPeriod,
+			&project.RoundingMode,
+			&roundingIncrementSeconds,
+			&project.RoundingScope,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		setProjectBudget(&project, budgetSeconds)
+		setProjectRoundingIncrement(&project, roundingIncrementSeconds)
+
 		// Parse last tracked time if present
 		if lastTrackedAtStr != nil {
 			// Try different time formats
@@ -209,6 +315,16 @@ func (r *project) DeleteByID(ctx context.Context, id int) error {
 	return err
 }
 
+// DeleteAll deletes all projects, which deletes their time entries, pauses and category rates as well
+func (r *project) DeleteAll(ctx context.Context) error {
+	query, args, err := goqu.Delete(projectTable).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 // GetByIDOrName retrieves a project by ID (if numeric) or name
 func (r *project) GetByIDOrName(ctx context.Context, idOrName string) (*model.Project, error) {
 	if id, err := strconv.Atoi(idOrName); err == nil {
@@ -217,3 +333,160 @@ func (r *project) GetByIDOrName(ctx context.Context, idOrName string) (*model.Pr
 
 	return r.GetByName(ctx, idOrName)
 }
+
+// UpdateRate updates the hourly rate and currency of a project
+func (r *project) UpdateRate(ctx context.Context, id int, hourlyRate *float64, currency *string) error {
+	record := goqu.Record{
+		"hourly_rate": nil,
+		"currency":    nil,
+	}
+
+	if hourlyRate != nil {
+		record["hourly_rate"] = *hourlyRate
+	}
+
+	if currency != nil {
+		record["currency"] = *currency
+	}
+
+	query, args, err := goqu.Update(projectTable).
+		Set(record).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// UpdateBudget updates the time budget and budget period of a project
+func (r *project) UpdateBudget(ctx context.Context, id int, budget *time.Duration, period *string) error {
+	record := goqu.Record{
+		"budget":        nil,
+		"budget_period": nil,
+	}
+
+	if budget != nil {
+		record["budget"] = int64(budget.Seconds())
+	}
+
+	if period != nil {
+		record["budget_period"] = *period
+	}
+
+	query, args, err := goqu.Update(projectTable).
+		Set(record).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// UpdateRounding updates the rounding rule which overrides the configured rounding for a project
+func (r *project) UpdateRounding(ctx context.Context, id int, rounding *model.Rounding) error {
+	record := goqu.Record{
+		"rounding_mode":      nil,
+		"rounding_increment": nil,
+		"rounding_scope":     nil,
+	}
+
+	if rounding != nil {
+		record["rounding_mode"] = rounding.Mode
+		record["rounding_increment"] = int64(rounding.Increment.Seconds())
+		record["rounding_scope"] = rounding.Scope
+	}
+
+	query, args, err := goqu.Update(projectTable).
+		Set(record).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// SetCategoryRate sets the hourly rate which overrides the project rate for a category
+func (r *project) SetCategoryRate(ctx context.Context, projectID int, category string, hourlyRate float64) error {
+	query, args, err := goqu.Insert(categoryRateTable).
+		Rows(goqu.Record{
+			"project_id":  projectID,
+			"category":    category,
+			"hourly_rate": hourlyRate,
+		}).
+		OnConflict(goqu.DoUpdate("project_id, category", goqu.Record{"hourly_rate": hourlyRate})).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteCategoryRate deletes the hourly rate of a category within a project
+func (r *project) DeleteCategoryRate(ctx context.Context, projectID int, category string) error {
+	query, args, err := goqu.Delete(categoryRateTable).
+		Where(
+			goqu.C("project_id").Eq(projectID),
+			goqu.C("category").Eq(category),
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// GetCategoryRates retrieves all category rates
+func (r *project) GetCategoryRates(ctx context.Context) ([]model.CategoryRate, error) {
+	query, args, err := goqu.From(categoryRateTable).
+		Select("project_id", "category", "hourly_rate").
+		Order(goqu.C("project_id").Asc(), goqu.C("category").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []model.CategoryRate
+	for rows.Next() {
+		var rate model.CategoryRate
+		if err := rows.Scan(&rate.ProjectID, &rate.Category, &rate.HourlyRate); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+// setProjectBudget sets the budget of a project from its stored number of seconds
+func setProjectBudget(project *model.Project, budgetSeconds *int64) {
+	if budgetSeconds == nil {
+		return
+	}
+
+	budget := time.Duration(*budgetSeconds) * time.Second
+	project.Budget = &budget
+}
+
+// setProjectRoundingIncrement sets the rounding increment of a project from its stored number of seconds
+func setProjectRoundingIncrement(project *model.Project, incrementSeconds *int64) {
+	if incrementSeconds == nil {
+		return
+	}
+
+	increment := time.Duration(*incrementSeconds) * time.Second
+	project.RoundingIncrement = &increment
+}
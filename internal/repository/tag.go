@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+const (
+	tagTable          = "tags"
+	timeEntryTagTable = "time_entry_tags"
+)
+
+// TagWithUsage represents a tag with the number of time entries it is assigned to
+type TagWithUsage struct {
+	model.Tag
+	UsageCount int `json:"usage_count"`
+}
+
+// Tag defines the interface for tag data operations
+type Tag interface {
+	// GetByName retrieves a tag by its name
+	GetByName(ctx context.Context, name string) (*model.Tag, error)
+	// GetOrCreate retrieves a tag by name, or creates it if it doesn't exist
+	GetOrCreate(ctx context.Context, name string) (*model.Tag, error)
+	// GetAllWithUsage retrieves all tags with the number of time entries they are assigned to
+	GetAllWithUsage(ctx context.Context) ([]TagWithUsage, error)
+	// SetForTimeEntry replaces the tags of a time entry with the given tag names
+	SetForTimeEntry(ctx context.Context, timeEntryID int, names []string) error
+	// DeleteByTimeEntry removes all tags from a specific time entry
+	DeleteByTimeEntry(ctx context.Context, timeEntryID int) error
+	// DeleteByProject removes all tags from the time entries of a specific project
+	DeleteByProject(ctx context.Context, projectID int) error
+	// DeleteAll deletes all tags and their assignments
+	DeleteAll(ctx context.Context) error
+}
+
+type tag struct {
+	db dbtx
+}
+
+// NewTag creates a new tag repository
+func NewTag(db *sql.DB) Tag {
+	return &tag{db: db}
+}
+
+// GetByName retrieves a tag by its name
+func (r *tag) GetByName(ctx context.Context, name string) (*model.Tag, error) {
+	query, args, err := goqu.From(tagTable).
+		Select("id", "name", "created_at").
+		Where(goqu.C("name").Eq(name)).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	var tag model.Tag
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// GetOrCreate retrieves a tag by name, or creates it if it doesn't exist
+func (r *tag) GetOrCreate(ctx context.Context, name string) (*model.Tag, error) {
+	// Try to get existing tag first
+	existing, err := r.GetByName(ctx, name)
+	if err == nil {
+		return existing, nil
+	}
+
+	query, args, err := goqu.Insert(tagTable).Rows(goqu.Record{
+		"name": name,
+	}).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByName(ctx, name)
+}
+
+// GetAllWithUsage retrieves all tags with the number of time entries they are assigned to
+func (r *tag) GetAllWithUsage(ctx context.Context) ([]TagWithUsage, error) {
+	query, args, err := goqu.From(goqu.T(tagTable).As("tg")).
+		LeftJoin(goqu.T(timeEntryTagTable).As("tet"), goqu.On(goqu.I("tg.id").Eq(goqu.I("tet.tag_id")))).
+		Select(
+			goqu.I("tg.id"),
+			goqu.I("tg.name"),
+			goqu.I("tg.created_at"),
+			goqu.COUNT(goqu.I("tet.time_entry_id")).As("usage_count"),
+		).
+		GroupBy(goqu.I("tg.id"), goqu.I("tg.name"), goqu.I("tg.created_at")).
+		Order(goqu.I("tg.name").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []TagWithUsage
+	for rows.Next() {
+		var tag TagWithUsage
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.UsageCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// SetForTimeEntry replaces the tags of a time entry with the given tag names
+func (r *tag) SetForTimeEntry(ctx context.Context, timeEntryID int, names []string) error {
+	if err := r.DeleteByTimeEntry(ctx, timeEntryID); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		tag, err := r.GetOrCreate(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		query, args, err := goqu.Insert(timeEntryTagTable).Rows(goqu.Record{
+			"time_entry_id": timeEntryID,
+			"tag_id":        tag.ID,
+		}).ToSQL()
+		if err != nil {
+			return err
+		}
+
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteByTimeEntry removes all tags from a specific time entry
+func (r *tag) DeleteByTimeEntry(ctx context.Context, timeEntryID int) error {
+	query, args, err := goqu.Delete(timeEntryTagTable).
+		Where(goqu.C("time_entry_id").Eq(timeEntryID)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteByProject removes all tags from the time entries of a specific project
+func (r *tag) DeleteByProject(ctx context.Context, projectID int) error {
+	query, args, err := goqu.Delete(timeEntryTagTable).
+		Where(goqu.C("time_entry_id").In(
+			goqu.From(timeEntryTable).Select("id").Where(goqu.C("project_id").Eq(projectID)),
+		)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// DeleteAll deletes all tags and their assignments
+func (r *tag) DeleteAll(ctx context.Context) error {
+	query, args, err := goqu.Delete(timeEntryTagTable).ToSQL()
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = goqu.Delete(tagTable).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
@@ -21,14 +23,38 @@ type TimeEntryWithPauses struct {
 	PauseTime  time.Duration `json:"pause_time"`
 }
 
+// TimeEntryFilter defines optional criteria time entries have to match. Nil or empty fields are not applied.
+type TimeEntryFilter struct {
+	// Since and Until are the inclusive lower and exclusive upper bound of the start time
+	Since    *time.Time
+	Until    *time.Time
+	Category *string
+	Tags     []string
+	// MatchAllTags requires entries to carry all Tags instead of at least one of them
+	MatchAllTags bool
+}
+
 // TimeEntry defines the interface for time entry data operations
 type TimeEntry interface {
 	// Create creates a new time entry
-	Create(ctx context.Context, projectID int, startTime time.Time, category *string) (*model.TimeEntry, error)
+	Create(ctx context.Context, projectID int, startTime time.Time, category *string, notes *string, billable bool) (*model.TimeEntry, error)
+	// CreateCompleted creates a new time entry which already has an end time and work duration
+	CreateCompleted(ctx context.Context, projectID int, startTime time.Time, endTime time.Time, duration time.Duration, category *string, notes *string, billable bool) (*model.TimeEntry, error)
+	// Insert inserts a time entry with all its fields except the tags, e.g. from a backup. A zero ID is assigned by
+	// the database.
+	Insert(ctx context.Context, entry model.TimeEntry) (int, error)
 	// GetByID retrieves a time entry by its ID
 	GetByID(ctx context.Context, id int) (*model.TimeEntry, error)
+	// GetByProjectAndStartTime retrieves the time entry of a project which started within the second of the given time
+	GetByProjectAndStartTime(ctx context.Context, projectID int, startTime time.Time) (*model.TimeEntry, error)
 	// GetActive retrieves the currently active time entry
 	GetActive(ctx context.Context) (*model.TimeEntry, error)
+	// GetOverlapping retrieves all time entries which overlap with the given time range
+	GetOverlapping(ctx context.Context, startTime time.Time, endTime time.Time) ([]model.TimeEntry, error)
+	// Update updates the project, start time, end time, duration, category, notes and billable flag of a time entry
+	Update(ctx context.Context, entry *model.TimeEntry) error
+	// UpdateNotes updates the notes of a time entry
+	UpdateNotes(ctx context.Context, id int, notes *string) error
 	// UpdateEndTime updates the end time and duration of a time entry
 	UpdateEndTime(ctx context.Context, id int, endTime time.Time, duration time.Duration) error
 	// StopAllActive stops all active time entries by setting their end time
@@ -40,17 +66,23 @@ type TimeEntry interface {
 	// GetByProjectIDOrName retrieves time entries for a project by ID (if numeric) or name
 	GetByProjectIDOrName(ctx context.Context, projectIDOrName string, limit int, sortOrder string) ([]model.TimeEntry, error)
 	// GetByProjectIDOrNameWithPauses retrieves time entries with pause information for a project by ID (if numeric) or name
-	GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error)
+	GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]TimeEntryWithPauses, error)
 	// GetTotalTimeByProjectIDOrName retrieves the total tracked time for a project by ID (if numeric) or name
-	GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time) (time.Duration, error)
+	GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time, until *time.Time) (time.Duration, error)
+	// GetWorkTimeByProject retrieves the work time (excluding pauses) of all completed entries of a project
+	GetWorkTimeByProject(ctx context.Context, projectID int, since *time.Time) (time.Duration, error)
 	// GetAllWithPauses retrieves all time entries with pause information across all projects
 	GetAllWithPauses(ctx context.Context, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error)
 	// GetAllWithPausesByCategory retrieves all time entries with pause information across all projects filtered by category
 	GetAllWithPausesByCategory(ctx context.Context, limit int, sortOrder string, since *time.Time, category *string) ([]TimeEntryWithPauses, error)
+	// GetAllWithPausesFiltered retrieves all time entries with pause information across all projects matching the filter
+	GetAllWithPausesFiltered(ctx context.Context, limit int, sortOrder string, filter TimeEntryFilter) ([]TimeEntryWithPauses, error)
 	// GetCategories retrieves all unique categories from time entries
 	GetCategories(ctx context.Context) ([]string, error)
 	// GetAll retrieves all time entries with a limit
 	GetAll(ctx context.Context, limit int) ([]model.TimeEntry, error)
+	// DeleteByID deletes a time entry by its ID
+	DeleteByID(ctx context.Context, id int) error
 	// DeleteByProject deletes all time entries for a specific project
 	DeleteByProject(ctx context.Context, projectID int) error
 	// DeleteAll deletes all time entries
@@ -59,7 +91,7 @@ type TimeEntry interface {
 
 // timeEntry implements TimeEntry using SQLite
 type timeEntry struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewTimeEntry creates a new time entry repository
@@ -68,16 +100,21 @@ func NewTimeEntry(db *sql.DB) TimeEntry {
 }
 
 // Create creates a new time entry
-func (r *timeEntry) Create(ctx context.Context, projectID int, startTime time.Time, category *string) (*model.TimeEntry, error) {
+func (r *timeEntry) Create(ctx context.Context, projectID int, startTime time.Time, category *string, notes *string, billable bool) (*model.TimeEntry, error) {
 	record := goqu.Record{
 		"project_id": projectID,
 		"start_time": startTime,
+		"billable":   billable,
 	}
 
 	if category != nil {
 		record["category"] = *category
 	}
 
+	if notes != nil {
+		record["notes"] = *notes
+	}
+
 	query, args, err := goqu.Insert(timeEntryTable).Rows(record).ToSQL()
 	if err != nil {
 		return nil, err
@@ -96,6 +133,90 @@ func (r *timeEntry) Create(ctx context.Context, projectID int, startTime time.Ti
 	return r.GetByID(ctx, int(id))
 }
 
+// CreateCompleted creates a new time entry which already has an end time and work duration
+func (r *timeEntry) CreateCompleted(ctx context.Context, projectID int, startTime time.Time, endTime time.Time, duration time.Duration, category *string, notes *string, billable bool) (*model.TimeEntry, error) {
+	record := goqu.Record{
+		"project_id": projectID,
+		"start_time": startTime,
+		"end_time":   endTime,
+		"duration":   int64(duration.Seconds()),
+		"billable":   billable,
+	}
+
+	if category != nil {
+		record["category"] = *category
+	}
+
+	if notes != nil {
+		record["notes"] = *notes
+	}
+
+	query, args, err := goqu.Insert(timeEntryTable).Rows(record).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, int(id))
+}
+
+// Insert inserts a time entry with all its fields except the tags, e.g. from a backup. A zero ID is assigned by the
+// database.
+func (r *timeEntry) Insert(ctx context.Context, entry model.TimeEntry) (int, error) {
+	record := goqu.Record{
+		"project_id": entry.ProjectID,
+		"start_time": entry.StartTime,
+		"end_time":   entry.EndTime,
+		"duration":   nil,
+		"category":   entry.Category,
+		"notes":      entry.Notes,
+		"billable":   entry.Billable,
+		"created_at": entry.CreatedAt,
+	}
+
+	if entry.ID != 0 {
+		record["id"] = entry.ID
+	}
+
+	if entry.Duration != nil {
+		record["duration"] = int64(entry.Duration.Seconds())
+	}
+
+	return insertRecord(ctx, r.db, timeEntryTable, record)
+}
+
+// GetByProjectAndStartTime retrieves the time entry of a project which started within the second of the given time.
+// Tracked times are stored with fractions of a second, while exported and imported times only carry whole seconds.
+func (r *timeEntry) GetByProjectAndStartTime(ctx context.Context, projectID int, startTime time.Time) (*model.TimeEntry, error) {
+	// The stored UTC times start with the date and time up to the second
+	second := startTime.UTC().Format("2006-01-02T15:04:05")
+
+	query, args, err := goqu.From(timeEntryTable).
+		Select("id").
+		Where(goqu.C("project_id").Eq(projectID), goqu.Func("substr", goqu.C("start_time"), 1, len(second)).Eq(second)).
+		Limit(1).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
 // GetByID retrieves a time entry by its ID
 func (r *timeEntry) GetByID(ctx context.Context, id int) (*model.TimeEntry, error) {
 	query, args, err := goqu.From(goqu.T(timeEntryTable).As("te")).
@@ -106,6 +227,9 @@ func (r *timeEntry) GetByID(ctx context.Context, id int) (*model.TimeEntry, erro
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -122,6 +246,8 @@ func (r *timeEntry) GetByID(ctx context.Context, id int) (*model.TimeEntry, erro
 	var endTime *time.Time
 	var duration *int64
 	var category *string
+	var notes *string
+	var tags *string
 	var project model.Project
 
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(
@@ -131,6 +257,9 @@ func (r *timeEntry) GetByID(ctx context.Context, id int) (*model.TimeEntry, erro
 		&endTime,
 		&duration,
 		&category,
+		&notes,
+		&tags,
+		&entry.Billable,
 		&entry.CreatedAt,
 		&project.ID,
 		&project.Name,
@@ -146,6 +275,8 @@ func (r *timeEntry) GetByID(ctx context.Context, id int) (*model.TimeEntry, erro
 		entry.Duration = &d
 	}
 	entry.Category = category
+	entry.Notes = notes
+	entry.Tags = splitTagNames(tags)
 	entry.Project = &project
 
 	return &entry, nil
@@ -161,6 +292,9 @@ func (r *timeEntry) GetActive(ctx context.Context) (*model.TimeEntry, error) {
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -179,6 +313,8 @@ func (r *timeEntry) GetActive(ctx context.Context) (*model.TimeEntry, error) {
 	var endTime *time.Time
 	var duration *int64
 	var category *string
+	var notes *string
+	var tags *string
 	var project model.Project
 
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(
@@ -188,6 +324,9 @@ func (r *timeEntry) GetActive(ctx context.Context) (*model.TimeEntry, error) {
 		&endTime,
 		&duration,
 		&category,
+		&notes,
+		&tags,
+		&entry.Billable,
 		&entry.CreatedAt,
 		&project.ID,
 		&project.Name,
@@ -203,11 +342,111 @@ func (r *timeEntry) GetActive(ctx context.Context) (*model.TimeEntry, error) {
 		entry.Duration = &d
 	}
 	entry.Category = category
+	entry.Notes = notes
+	entry.Tags = splitTagNames(tags)
 	entry.Project = &project
 
 	return &entry, nil
 }
 
+// GetOverlapping retrieves all time entries which overlap with the given time range
+func (r *timeEntry) GetOverlapping(ctx context.Context, startTime time.Time, endTime time.Time) ([]model.TimeEntry, error) {
+	query, args, err := goqu.From(goqu.T(timeEntryTable).As("te")).
+		Select(
+			goqu.I("te.id"),
+			goqu.I("te.project_id"),
+			goqu.I("te.start_time"),
+			goqu.I("te.end_time"),
+			goqu.I("te.duration"),
+			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
+			goqu.I("te.created_at"),
+			goqu.I("p.id").As("project_id2"),
+			goqu.I("p.name").As("project_name"),
+			goqu.I("p.created_at").As("project_created_at"),
+		).
+		Join(goqu.T(projectTable).As("p"), goqu.On(goqu.I("te.project_id").Eq(goqu.I("p.id")))).
+		Where(
+			goqu.I("te.start_time").Lt(endTime),
+			goqu.Or(
+				goqu.I("te.end_time").IsNull(),
+				goqu.I("te.end_time").Gt(startTime),
+			),
+		).
+		Order(goqu.I("te.start_time").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTimeEntries(rows)
+}
+
+// Update updates the project, start time, end time, duration, category, notes and billable flag of a time entry
+func (r *timeEntry) Update(ctx context.Context, entry *model.TimeEntry) error {
+	record := goqu.Record{
+		"project_id": entry.ProjectID,
+		"start_time": entry.StartTime,
+		"end_time":   nil,
+		"duration":   nil,
+		"category":   nil,
+		"notes":      nil,
+		"billable":   entry.Billable,
+	}
+
+	if entry.EndTime != nil {
+		record["end_time"] = *entry.EndTime
+	}
+
+	if entry.Duration != nil {
+		record["duration"] = int64(entry.Duration.Seconds())
+	}
+
+	if entry.Category != nil {
+		record["category"] = *entry.Category
+	}
+
+	if entry.Notes != nil {
+		record["notes"] = *entry.Notes
+	}
+
+	query, args, err := goqu.Update(timeEntryTable).
+		Set(record).
+		Where(goqu.C("id").Eq(entry.ID)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// UpdateNotes updates the notes of a time entry
+func (r *timeEntry) UpdateNotes(ctx context.Context, id int, notes *string) error {
+	var value interface{}
+	if notes != nil {
+		value = *notes
+	}
+
+	query, args, err := goqu.Update(timeEntryTable).
+		Set(goqu.Record{"notes": value}).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 // UpdateEndTime updates the end time and duration of a time entry
 func (r *timeEntry) UpdateEndTime(ctx context.Context, id int, endTime time.Time, duration time.Duration) error {
 	durationSeconds := int64(duration.Seconds())
@@ -262,6 +501,9 @@ func (r *timeEntry) GetByProject(ctx context.Context, projectID int, limit int,
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -310,6 +552,9 @@ func (r *timeEntry) GetByProjectName(ctx context.Context, projectName string, li
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -343,7 +588,7 @@ func (r *timeEntry) GetAll(ctx context.Context, limit int) ([]model.TimeEntry, e
 	query, args, err := goqu.From(timeEntryTable).
 		Join(goqu.T(projectTable), goqu.On(goqu.C("time_entries.project_id").Eq(goqu.C("projects.id")))).
 		Select(
-			"time_entries.id", "time_entries.project_id", "time_entries.start_time", "time_entries.end_time", "time_entries.duration", "time_entries.category", "time_entries.created_at",
+			"time_entries.id", "time_entries.project_id", "time_entries.start_time", "time_entries.end_time", "time_entries.duration", "time_entries.category", "time_entries.notes", goqu.L(tagNamesSubquery(timeEntryTable)).As("tags"), "time_entries.billable", "time_entries.created_at",
 			goqu.C("projects.id").As("project_id2"), goqu.C("projects.name").As("project_name"), goqu.C("projects.created_at").As("project_created_at"),
 		).
 		Order(goqu.C("time_entries.start_time").Desc()).
@@ -372,16 +617,16 @@ func (r *timeEntry) GetByProjectIDOrName(ctx context.Context, projectIDOrName st
 }
 
 // GetByProjectIDOrNameWithPauses retrieves time entries with pause information for a project by ID (if numeric) or name
-func (r *timeEntry) GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error) {
+func (r *timeEntry) GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]TimeEntryWithPauses, error) {
 	if projectID, err := strconv.Atoi(projectIDOrName); err == nil {
-		return r.GetByProjectWithPauses(ctx, projectID, limit, sortOrder, since)
+		return r.GetByProjectWithPauses(ctx, projectID, limit, sortOrder, since, until)
 	}
 
-	return r.GetByProjectNameWithPauses(ctx, projectIDOrName, limit, sortOrder, since)
+	return r.GetByProjectNameWithPauses(ctx, projectIDOrName, limit, sortOrder, since, until)
 }
 
 // GetByProjectWithPauses retrieves time entries with pause information for a specific project
-func (r *timeEntry) GetByProjectWithPauses(ctx context.Context, projectID int, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error) {
+func (r *timeEntry) GetByProjectWithPauses(ctx context.Context, projectID int, limit int, sortOrder string, since *time.Time, until *time.Time) ([]TimeEntryWithPauses, error) {
 	var orderDirection string
 	switch sortOrder {
 	case "asc":
@@ -411,6 +656,9 @@ func (r *timeEntry) GetByProjectWithPauses(ctx context.Context, projectID int, l
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -426,6 +674,10 @@ func (r *timeEntry) GetByProjectWithPauses(ctx context.Context, projectID int, l
 		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Gte(*since))
 	}
 
+	if until != nil {
+		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Lt(*until))
+	}
+
 	if orderDirection == "ASC" {
 		queryBuilder = queryBuilder.Order(goqu.I("te.start_time").Asc())
 	} else {
@@ -447,7 +699,7 @@ func (r *timeEntry) GetByProjectWithPauses(ctx context.Context, projectID int, l
 }
 
 // GetByProjectNameWithPauses retrieves time entries with pause information for a project by name
-func (r *timeEntry) GetByProjectNameWithPauses(ctx context.Context, projectName string, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error) {
+func (r *timeEntry) GetByProjectNameWithPauses(ctx context.Context, projectName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]TimeEntryWithPauses, error) {
 	var orderDirection string
 	switch sortOrder {
 	case "asc":
@@ -477,6 +729,9 @@ func (r *timeEntry) GetByProjectNameWithPauses(ctx context.Context, projectName
 			goqu.I("te.end_time"),
 			goqu.I("te.duration"),
 			goqu.I("te.category"),
+			goqu.I("te.notes"),
+			goqu.L(tagNamesSubquery("te")).As("tags"),
+			goqu.I("te.billable"),
 			goqu.I("te.created_at"),
 			goqu.I("p.id").As("project_id2"),
 			goqu.I("p.name").As("project_name"),
@@ -492,6 +747,10 @@ func (r *timeEntry) GetByProjectNameWithPauses(ctx context.Context, projectName
 		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Gte(*since))
 	}
 
+	if until != nil {
+		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Lt(*until))
+	}
+
 	if orderDirection == "ASC" {
 		queryBuilder = queryBuilder.Order(goqu.I("te.start_time").Asc())
 	} else {
@@ -525,6 +784,8 @@ func (r *timeEntry) scanTimeEntriesWithPauses(rows *sql.Rows) ([]TimeEntryWithPa
 		var totalPauseTimeSeconds int64
 		var durationSeconds *int64
 		var category *string
+		var notes *string
+		var tags *string
 
 		err := rows.Scan(
 			&entry.ID,
@@ -533,6 +794,9 @@ func (r *timeEntry) scanTimeEntriesWithPauses(rows *sql.Rows) ([]TimeEntryWithPa
 			&entry.EndTime,
 			&durationSeconds,
 			&category,
+			&notes,
+			&tags,
+			&entry.Billable,
 			&entry.CreatedAt,
 			&projectID2,
 			&projectName,
@@ -550,6 +814,8 @@ func (r *timeEntry) scanTimeEntriesWithPauses(rows *sql.Rows) ([]TimeEntryWithPa
 		}
 
 		entry.Category = category
+		entry.Notes = notes
+		entry.Tags = splitTagNames(tags)
 
 		entry.Project = &model.Project{
 			ID:        projectID2,
@@ -566,6 +832,18 @@ func (r *timeEntry) scanTimeEntriesWithPauses(rows *sql.Rows) ([]TimeEntryWithPa
 	return entries, rows.Err()
 }
 
+// DeleteByID deletes a time entry by its ID
+func (r *timeEntry) DeleteByID(ctx context.Context, id int) error {
+	query, args, err := goqu.Delete(timeEntryTable).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 // DeleteByProject deletes all time entries for a specific project
 func (r *timeEntry) DeleteByProject(ctx context.Context, projectID int) error {
 	query, args, err := goqu.Delete(timeEntryTable).
@@ -588,6 +866,25 @@ func (r *timeEntry) DeleteAll(ctx context.Context) error {
 	return err
 }
 
+// tagNamesSubquery returns a subquery selecting the comma separated tag names of the referenced time entry
+func tagNamesSubquery(timeEntryRef string) string {
+	return fmt.Sprintf(
+		`(SELECT GROUP_CONCAT(tg.name) FROM %s tet JOIN %s tg ON tg.id = tet.tag_id WHERE tet.time_entry_id = %s.id)`,
+		timeEntryTagTable, tagTable, timeEntryRef,
+	)
+}
+
+// splitTagNames splits comma separated tag names into a sorted slice
+func splitTagNames(tagNames *string) []string {
+	if tagNames == nil || *tagNames == "" {
+		return nil
+	}
+
+	tags := strings.Split(*tagNames, ",")
+	sort.Strings(tags)
+	return tags
+}
+
 // scanTimeEntries is a helper method to scan time entries from rows
 func (r *timeEntry) scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
 	var entries []model.TimeEntry
@@ -597,6 +894,8 @@ func (r *timeEntry) scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
 		var endTime *time.Time
 		var duration *int64
 		var category *string
+		var notes *string
+		var tags *string
 		var project model.Project
 
 		err := rows.Scan(
@@ -606,6 +905,9 @@ func (r *timeEntry) scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
 			&endTime,
 			&duration,
 			&category,
+			&notes,
+			&tags,
+			&entry.Billable,
 			&entry.CreatedAt,
 			&project.ID,
 			&project.Name,
@@ -621,6 +923,8 @@ func (r *timeEntry) scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
 			entry.Duration = &d
 		}
 		entry.Category = category
+		entry.Notes = notes
+		entry.Tags = splitTagNames(tags)
 		entry.Project = &project
 
 		entries = append(entries, entry)
@@ -630,16 +934,16 @@ func (r *timeEntry) scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
 }
 
 // GetTotalTimeByProjectIDOrName retrieves the total tracked time for a project by ID (if numeric) or name
-func (r *timeEntry) GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time) (time.Duration, error) {
+func (r *timeEntry) GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time, until *time.Time) (time.Duration, error) {
 	if projectID, err := strconv.Atoi(projectIDOrName); err == nil {
-		return r.GetTotalTimeByProject(ctx, projectID, since)
+		return r.GetTotalTimeByProject(ctx, projectID, since, until)
 	}
 
-	return r.GetTotalTimeByProjectName(ctx, projectIDOrName, since)
+	return r.GetTotalTimeByProjectName(ctx, projectIDOrName, since, until)
 }
 
 // GetTotalTimeByProject retrieves the total tracked time for a specific project
-func (r *timeEntry) GetTotalTimeByProject(ctx context.Context, projectID int, since *time.Time) (time.Duration, error) {
+func (r *timeEntry) GetTotalTimeByProject(ctx context.Context, projectID int, since *time.Time, until *time.Time) (time.Duration, error) {
 	pauseStatsSubquery := goqu.From(pauseTable).
 		Select(
 			goqu.I("time_entry_id"),
@@ -666,6 +970,10 @@ func (r *timeEntry) GetTotalTimeByProject(ctx context.Context, projectID int, si
 		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Gte(*since))
 	}
 
+	if until != nil {
+		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Lt(*until))
+	}
+
 	query, args, err := queryBuilder.ToSQL()
 	if err != nil {
 		return 0, err
@@ -681,7 +989,7 @@ func (r *timeEntry) GetTotalTimeByProject(ctx context.Context, projectID int, si
 }
 
 // GetTotalTimeByProjectName retrieves the total tracked time for a project by name
-func (r *timeEntry) GetTotalTimeByProjectName(ctx context.Context, projectName string, since *time.Time) (time.Duration, error) {
+func (r *timeEntry) GetTotalTimeByProjectName(ctx context.Context, projectName string, since *time.Time, until *time.Time) (time.Duration, error) {
 	pauseStatsSubquery := goqu.From(pauseTable).
 		Select(
 			goqu.I("time_entry_id"),
@@ -708,6 +1016,10 @@ func (r *timeEntry) GetTotalTimeByProjectName(ctx context.Context, projectName s
 		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Gte(*since))
 	}
 
+	if until != nil {
+		queryBuilder = queryBuilder.Where(goqu.I("te.start_time").Lt(*until))
+	}
+
 	query, args, err := queryBuilder.ToSQL()
 	if err != nil {
 		return 0, err
@@ -722,58 +1034,45 @@ func (r *timeEntry) GetTotalTimeByProjectName(ctx context.Context, projectName s
 	return time.Duration(totalTimeSeconds) * time.Second, nil
 }
 
-// GetAllWithPauses retrieves all time entries with pause information across all projects
-func (r *timeEntry) GetAllWithPauses(ctx context.Context, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error) {
-	var orderClause string
-	switch sortOrder {
-	case "asc":
-		orderClause = "ORDER BY te.start_time ASC"
-	case "desc":
-		orderClause = "ORDER BY te.start_time DESC"
-	default:
-		orderClause = "ORDER BY te.start_time DESC"
-	}
-
-	query := fmt.Sprintf(`
-		SELECT te.id, te.project_id, te.start_time, te.end_time, te.duration, te.category, te.created_at,
-		       p.id, p.name, p.created_at,
-		       COALESCE(pause_stats.pause_count, 0) as pause_count,
-		       COALESCE(pause_stats.total_pause_time, 0) as total_pause_time
-		FROM %s te
-		JOIN %s p ON te.project_id = p.id
-		LEFT JOIN (
-			SELECT time_entry_id,
-			       COUNT(*) as pause_count,
-			       SUM(COALESCE(duration, 0)) as total_pause_time
-			FROM %s
-			WHERE pause_end IS NOT NULL
-			GROUP BY time_entry_id
-		) pause_stats ON te.id = pause_stats.time_entry_id`, timeEntryTable, projectTable, pauseTable)
-
-	args := []interface{}{}
+// GetWorkTimeByProject retrieves the work time (excluding pauses) of all completed entries of a project
+func (r *timeEntry) GetWorkTimeByProject(ctx context.Context, projectID int, since *time.Time) (time.Duration, error) {
+	queryBuilder := goqu.From(timeEntryTable).
+		Select(goqu.COALESCE(goqu.SUM(goqu.C("duration")), 0).As("work_time")).
+		Where(
+			goqu.C("project_id").Eq(projectID),
+			goqu.C("end_time").IsNotNull(),
+		)
 
 	if since != nil {
-		query += ` WHERE te.start_time >= ?`
-		args = append(args, *since)
+		queryBuilder = queryBuilder.Where(goqu.C("start_time").Gte(*since))
 	}
 
-	query += ` ` + orderClause
-
-	// limit
-	query += ` LIMIT ?`
-	args = append(args, limit)
+	query, args, err := queryBuilder.ToSQL()
+	if err != nil {
+		return 0, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	var workTimeSeconds int64
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&workTimeSeconds)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
 
-	return r.scanTimeEntriesWithPauses(rows)
+	return time.Duration(workTimeSeconds) * time.Second, nil
+}
+
+// GetAllWithPauses retrieves all time entries with pause information across all projects
+func (r *timeEntry) GetAllWithPauses(ctx context.Context, limit int, sortOrder string, since *time.Time) ([]TimeEntryWithPauses, error) {
+	return r.GetAllWithPausesFiltered(ctx, limit, sortOrder, TimeEntryFilter{Since: since})
 }
 
 // GetAllWithPausesByCategory retrieves all time entries with pause information across all projects filtered by category
 func (r *timeEntry) GetAllWithPausesByCategory(ctx context.Context, limit int, sortOrder string, since *time.Time, category *string) ([]TimeEntryWithPauses, error) {
+	return r.GetAllWithPausesFiltered(ctx, limit, sortOrder, TimeEntryFilter{Since: since, Category: category})
+}
+
+// GetAllWithPausesFiltered retrieves all time entries with pause information across all projects matching the filter
+func (r *timeEntry) GetAllWithPausesFiltered(ctx context.Context, limit int, sortOrder string, filter TimeEntryFilter) ([]TimeEntryWithPauses, error) {
 	var orderClause string
 	switch sortOrder {
 	case "asc":
@@ -785,7 +1084,7 @@ func (r *timeEntry) GetAllWithPausesByCategory(ctx context.Context, limit int, s
 	}
 
 	query := fmt.Sprintf(`
-		SELECT te.id, te.project_id, te.start_time, te.end_time, te.duration, te.category, te.created_at,
+		SELECT te.id, te.project_id, te.start_time, te.end_time, te.duration, te.category, te.notes, %s AS tags, te.billable, te.created_at,
 		       p.id, p.name, p.created_at,
 		       COALESCE(pause_stats.pause_count, 0) as pause_count,
 		       COALESCE(pause_stats.total_pause_time, 0) as total_pause_time
@@ -798,26 +1097,49 @@ func (r *timeEntry) GetAllWithPausesByCategory(ctx context.Context, limit int, s
 			FROM %s
 			WHERE pause_end IS NOT NULL
 			GROUP BY time_entry_id
-		) pause_stats ON te.id = pause_stats.time_entry_id`, timeEntryTable, projectTable, pauseTable)
+		) pause_stats ON te.id = pause_stats.time_entry_id`, tagNamesSubquery("te"), timeEntryTable, projectTable, pauseTable)
 
 	args := []interface{}{}
 	whereClauses := []string{}
 
-	if since != nil {
+	if filter.Since != nil {
 		whereClauses = append(whereClauses, "te.start_time >= ?")
-		args = append(args, *since)
+		args = append(args, formatStoredTime(*filter.Since))
 	}
 
-	if category != nil {
+	if filter.Until != nil {
+		whereClauses = append(whereClauses, "te.start_time < ?")
+		args = append(args, formatStoredTime(*filter.Until))
+	}
+
+	if filter.Category != nil {
 		whereClauses = append(whereClauses, "te.category = ?")
-		args = append(args, *category)
+		args = append(args, *filter.Category)
 	}
 
-	if len(whereClauses) > 0 {
-		query += " WHERE " + whereClauses[0]
-		for i := 1; i < len(whereClauses); i++ {
-			query += " AND " + whereClauses[i]
+	if len(filter.Tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Tags)), ", ")
+		tagClause := fmt.Sprintf(`te.id IN (
+			SELECT tet.time_entry_id
+			FROM %s tet
+			JOIN %s tg ON tg.id = tet.tag_id
+			WHERE tg.name IN (%s)
+			GROUP BY tet.time_entry_id`, timeEntryTagTable, tagTable, placeholders)
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+
+		// Entries have to carry every given tag instead of at least one of them
+		if filter.MatchAllTags {
+			tagClause += ` HAVING COUNT(DISTINCT tg.name) = ?`
+			args = append(args, len(filter.Tags))
 		}
+
+		whereClauses = append(whereClauses, tagClause+")")
+	}
+
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
 	// sorting
@@ -836,6 +1158,12 @@ func (r *timeEntry) GetAllWithPausesByCategory(ctx context.Context, limit int, s
 	return r.scanTimeEntriesWithPauses(rows)
 }
 
+// formatStoredTime formats a time like goqu stores it, as UTC RFC 3339 string, so raw queries compare it with the
+// stored times correctly regardless of the local time zone
+func formatStoredTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // GetCategories retrieves all unique categories from time entries
 func (r *timeEntry) GetCategories(ctx context.Context) ([]string, error) {
 	query, args, err := goqu.From(timeEntryTable).
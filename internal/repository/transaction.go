@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+// dbtx defines the database operations the repositories need, which are provided by both *sql.DB and *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertRecord inserts a record into the given table and returns the ID of the inserted row
+func insertRecord(ctx context.Context, db dbtx, table string, record goqu.Record) (int, error) {
+	query, args, err := goqu.Insert(table).Rows(record).ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// Repositories bundles all repositories which operate on the same database transaction
+type Repositories struct {
+	Project   Project
+	TimeEntry TimeEntry
+	Pause     Pause
+	Tag       Tag
+	Absence   Absence
+}
+
+// Transactor defines the interface for running multiple repository operations atomically
+type Transactor interface {
+	// WithinTransaction runs fn with repositories bound to a new database transaction.
+	// The transaction is committed if fn returns no error and rolled back otherwise.
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new transactor
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTransaction runs fn with repositories bound to a new database transaction
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repos := Repositories{
+		Project:   &project{db: tx},
+		TimeEntry: &timeEntry{db: tx},
+		Pause:     &pause{db: tx},
+		Tag:       &tag{db: tx},
+		Absence:   &absence{db: tx},
+	}
+
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// AddAbsence adds a full or half day absence. The absences of a day must not exceed a full day.
+func (s *timeTracking) AddAbsence(ctx context.Context, date time.Time, absenceType string, halfDay bool, note *string) (*model.Absence, error) {
+	if !slices.Contains(model.AbsenceTypes, absenceType) {
+		return nil, fmt.Errorf("invalid absence type '%s', must be one of %v", absenceType, model.AbsenceTypes)
+	}
+
+	day := startOfDay(date)
+	existing, err := s.absenceRepo.GetInRange(ctx, &day, &day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	absence := model.Absence{Date: day, Type: absenceType, HalfDay: halfDay, Note: note}
+	if absenceFraction(existing)+absence.Fraction() > 1 {
+		return nil, fmt.Errorf("absences on %s would exceed a full day", day.Format(time.DateOnly))
+	}
+
+	created, err := s.absenceRepo.Create(ctx, day, absenceType, halfDay, note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create absence: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetAbsences returns all absences between the given days (both inclusive and optional)
+func (s *timeTracking) GetAbsences(ctx context.Context, since *time.Time, until *time.Time) ([]model.Absence, error) {
+	return s.absenceRepo.GetInRange(ctx, since, until)
+}
+
+// RemoveAbsence removes an absence by ID and returns the removed absence
+func (s *timeTracking) RemoveAbsence(ctx context.Context, id int) (*model.Absence, error) {
+	absence, err := s.absenceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("absence not found: %w", err)
+	}
+
+	if err := s.absenceRepo.DeleteByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to remove absence: %w", err)
+	}
+
+	return absence, nil
+}
+
+// ImportAbsences adds the given absences within a single transaction. Absences are skipped if the same type of
+// absence already exists on their day or if the day would exceed a full day of absence, so that importing the same
+// absences twice has no effect. It returns the number of added absences.
+func (s *timeTracking) ImportAbsences(ctx context.Context, absences []model.Absence) (int, error) {
+	imported := 0
+
+	err := s.transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		imported = 0
+
+		for _, absence := range absences {
+			if !slices.Contains(model.AbsenceTypes, absence.Type) {
+				return fmt.Errorf("invalid absence type '%s', must be one of %v", absence.Type, model.AbsenceTypes)
+			}
+
+			day := startOfDay(absence.Date)
+			existing, err := repos.Absence.GetInRange(ctx, &day, &day)
+			if err != nil {
+				return fmt.Errorf("failed to get absences: %w", err)
+			}
+
+			duplicate := slices.ContainsFunc(existing, func(a model.Absence) bool {
+				return a.Type == absence.Type
+			})
+			if duplicate || absenceFraction(existing)+absence.Fraction() > 1 {
+				continue
+			}
+
+			if _, err := repos.Absence.Create(ctx, day, absence.Type, absence.HalfDay, absence.Note); err != nil {
+				return fmt.Errorf("failed to create absence: %w", err)
+			}
+			imported++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return imported, nil
+}
+
+// AbsenceCredit holds the absences of a day and the part of the expected work time of the day they credit
+type AbsenceCredit struct {
+	Date     time.Time
+	Absences []model.Absence
+	Credited time.Duration
+}
+
+// getAbsenceCredits returns the credited time of every day with absences within the given period (end exclusive).
+// Absences credit their part of the expected work time of the schedule.
+func (s *timeTracking) getAbsenceCredits(ctx context.Context, schedule WorkSchedule, start time.Time, end time.Time) ([]AbsenceCredit, error) {
+	from := startOfDay(start)
+	until := startOfDay(end.Add(-time.Nanosecond))
+
+	absences, err := s.absenceRepo.GetInRange(ctx, &from, &until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	var credits []AbsenceCredit
+	for _, absence := range absences {
+		day := startOfDay(absence.Date)
+		if len(credits) == 0 || !credits[len(credits)-1].Date.Equal(day) {
+			credits = append(credits, AbsenceCredit{Date: day})
+		}
+		credit := &credits[len(credits)-1]
+		credit.Absences = append(credit.Absences, absence)
+	}
+
+	for i := range credits {
+		expected := schedule.Expected(credits[i].Date.Weekday())
+		credits[i].Credited = time.Duration(absenceFraction(credits[i].Absences) * float64(expected))
+	}
+
+	return credits, nil
+}
+
+// absenceFraction returns the part of a day which is covered by the given absences, at most a full day
+func absenceFraction(absences []model.Absence) float64 {
+	var fraction float64
+	for _, absence := range absences {
+		fraction += absence.Fraction()
+	}
+
+	return min(fraction, 1)
+}
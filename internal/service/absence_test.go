@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_AddAbsence(t *testing.T) {
+	ctx := context.Background()
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		absenceRepo: mockAbsenceRepo,
+	}
+
+	day := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	existing := []model.Absence{{ID: 1, Date: day, Type: model.AbsenceTypeSick, HalfDay: true}}
+	created := &model.Absence{ID: 2, Date: day, Type: model.AbsenceTypeVacation, HalfDay: true}
+
+	mockAbsenceRepo.On("GetInRange", ctx, &day, &day).Return(existing, nil)
+	mockAbsenceRepo.On("Create", ctx, day, model.AbsenceTypeVacation, true, (*string)(nil)).Return(created, nil)
+
+	absence, err := service.AddAbsence(ctx, day.Add(10*time.Hour), model.AbsenceTypeVacation, true, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, created, absence)
+	mockAbsenceRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_AddAbsence_ExceedsFullDay(t *testing.T) {
+	ctx := context.Background()
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		absenceRepo: mockAbsenceRepo,
+	}
+
+	day := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	existing := []model.Absence{{ID: 1, Date: day, Type: model.AbsenceTypeHoliday}}
+
+	mockAbsenceRepo.On("GetInRange", ctx, &day, &day).Return(existing, nil)
+
+	absence, err := service.AddAbsence(ctx, day, model.AbsenceTypeVacation, true, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, absence)
+	mockAbsenceRepo.AssertNotCalled(t, "Create")
+}
+
+func TestTimeTracking_AddAbsence_InvalidType(t *testing.T) {
+	service := &timeTracking{}
+
+	absence, err := service.AddAbsence(context.Background(), time.Now(), "party", false, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, absence)
+}
+
+func TestTimeTracking_ImportAbsences(t *testing.T) {
+	ctx := context.Background()
+	mockAbsenceRepo := &MockAbsenceRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Absence: mockAbsenceRepo},
+	}
+
+	service := &timeTracking{
+		absenceRepo: mockAbsenceRepo,
+		transactor:  mockTransactor,
+	}
+
+	christmas := time.Date(2025, 12, 25, 0, 0, 0, 0, time.Local)
+	boxingDay := christmas.AddDate(0, 0, 1)
+	note := "Boxing Day"
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockAbsenceRepo.On("GetInRange", ctx, &christmas, &christmas).Return([]model.Absence{{ID: 1, Date: christmas, Type: model.AbsenceTypeHoliday}}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, &boxingDay, &boxingDay).Return([]model.Absence{}, nil)
+	mockAbsenceRepo.On("Create", ctx, boxingDay, model.AbsenceTypeHoliday, false, &note).Return(&model.Absence{ID: 2}, nil)
+
+	imported, err := service.ImportAbsences(ctx, []model.Absence{
+		{Date: christmas, Type: model.AbsenceTypeHoliday},
+		{Date: boxingDay, Type: model.AbsenceTypeHoliday, Note: &note},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	mockAbsenceRepo.AssertExpectations(t)
+	mockTransactor.AssertExpectations(t)
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// BackupVersion is the version of the backup format written by CreateBackup
+const BackupVersion = 1
+
+const (
+	// RestoreModeReplace deletes all existing data and restores the backup with its original IDs
+	RestoreModeReplace = "replace"
+	// RestoreModeMerge adds the backup to the existing data, projects are matched by name and time entries which
+	// already exist for the same project and start time are skipped
+	RestoreModeMerge = "merge"
+)
+
+// Backup is a full copy of all tracked data including IDs and creation times. The tags of the time entries are
+// part of the entries.
+type Backup struct {
+	Version       int                  `json:"version"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Projects      []model.Project      `json:"projects"`
+	CategoryRates []model.CategoryRate `json:"category_rates"`
+	TimeEntries   []model.TimeEntry    `json:"time_entries"`
+	Pauses        []model.Pause        `json:"pauses"`
+	Absences      []model.Absence      `json:"absences"`
+}
+
+// RestoreResult holds the number of restored and skipped records of a restore
+type RestoreResult struct {
+	Projects           int
+	TimeEntries        int
+	SkippedTimeEntries int
+	Pauses             int
+	Absences           int
+	SkippedAbsences    int
+}
+
+// CreateBackup returns a backup of all projects, category rates, time entries, pauses and absences
+func (s *timeTracking) CreateBackup(ctx context.Context) (*Backup, error) {
+	projects, err := s.projectRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	rates, err := s.projectRepo.GetCategoryRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category rates: %w", err)
+	}
+
+	entries, err := s.timeEntryRepo.GetAllWithPausesFiltered(ctx, -1, "asc", repository.TimeEntryFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	pauses, err := s.pauseRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pauses: %w", err)
+	}
+
+	absences, err := s.absenceRepo.GetInRange(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	backup := &Backup{
+		Version:       BackupVersion,
+		CreatedAt:     time.Now(),
+		Projects:      make([]model.Project, len(projects)),
+		CategoryRates: append([]model.CategoryRate{}, rates...),
+		TimeEntries:   make([]model.TimeEntry, len(entries)),
+		Pauses:        append([]model.Pause{}, pauses...),
+		Absences:      append([]model.Absence{}, absences...),
+	}
+
+	for i, project := range projects {
+		// The last tracked time is derived from the time entries
+		project.LastTrackedAt = nil
+		backup.Projects[i] = project
+	}
+
+	for i, entry := range entries {
+		entry.Project = nil
+		backup.TimeEntries[i] = entry.TimeEntry
+	}
+
+	return backup, nil
+}
+
+// Validate checks the version of the backup and that all IDs are unique and all references point to records of the
+// backup, before anything is restored
+func (b *Backup) Validate() error {
+	if b.Version < 1 || b.Version > BackupVersion {
+		return fmt.Errorf("unsupported backup version %d, expected at most %d", b.Version, BackupVersion)
+	}
+
+	projectIDs := make(map[int]bool, len(b.Projects))
+	projectNames := make(map[string]bool, len(b.Projects))
+	for _, project := range b.Projects {
+		if project.ID <= 0 || projectIDs[project.ID] {
+			return fmt.Errorf("invalid or duplicate project ID %d", project.ID)
+		}
+		if project.Name == "" || projectNames[project.Name] {
+			return fmt.Errorf("empty or duplicate project name '%s'", project.Name)
+		}
+		projectIDs[project.ID] = true
+		projectNames[project.Name] = true
+	}
+
+	for _, rate := range b.CategoryRates {
+		if !projectIDs[rate.ProjectID] {
+			return fmt.Errorf("category rate '%s' references unknown project %d", rate.Category, rate.ProjectID)
+		}
+	}
+
+	entryIDs := make(map[int]bool, len(b.TimeEntries))
+	active := 0
+	for _, entry := range b.TimeEntries {
+		if entry.ID <= 0 || entryIDs[entry.ID] {
+			return fmt.Errorf("invalid or duplicate time entry ID %d", entry.ID)
+		}
+		if !projectIDs[entry.ProjectID] {
+			return fmt.Errorf("time entry %d references unknown project %d", entry.ID, entry.ProjectID)
+		}
+		if entry.EndTime == nil {
+			active++
+		} else if entry.EndTime.Before(entry.StartTime) {
+			return fmt.Errorf("time entry %d ends before it starts", entry.ID)
+		}
+		entryIDs[entry.ID] = true
+	}
+
+	if active > 1 {
+		return fmt.Errorf("backup contains %d active time entries, at most one is allowed", active)
+	}
+
+	pauseIDs := make(map[int]bool, len(b.Pauses))
+	for _, pause := range b.Pauses {
+		if pause.ID <= 0 || pauseIDs[pause.ID] {
+			return fmt.Errorf("invalid or duplicate pause ID %d", pause.ID)
+		}
+		if !entryIDs[pause.TimeEntryID] {
+			return fmt.Errorf("pause %d references unknown time entry %d", pause.ID, pause.TimeEntryID)
+		}
+		pauseIDs[pause.ID] = true
+	}
+
+	absenceIDs := make(map[int]bool, len(b.Absences))
+	for _, absence := range b.Absences {
+		if absence.ID <= 0 || absenceIDs[absence.ID] {
+			return fmt.Errorf("invalid or duplicate absence ID %d", absence.ID)
+		}
+		if !slices.Contains(model.AbsenceTypes, absence.Type) {
+			return fmt.Errorf("absence %d has invalid type '%s'", absence.ID, absence.Type)
+		}
+		absenceIDs[absence.ID] = true
+	}
+
+	return nil
+}
+
+// RestoreBackup validates the backup and restores it within a single transaction, so nothing is changed if any
+// record fails to restore
+func (s *timeTracking) RestoreBackup(ctx context.Context, backup *Backup, mode string) (*RestoreResult, error) {
+	if mode != RestoreModeReplace && mode != RestoreModeMerge {
+		return nil, fmt.Errorf("invalid restore mode '%s', must be %s or %s", mode, RestoreModeReplace, RestoreModeMerge)
+	}
+
+	if err := backup.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid backup: %w", err)
+	}
+
+	var result *RestoreResult
+	err := s.transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		if mode == RestoreModeReplace {
+			result, err = replaceBackup(ctx, repos, backup)
+		} else {
+			result, err = mergeBackup(ctx, repos, backup)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// replaceBackup deletes all data and inserts the records of the backup with their original IDs
+func replaceBackup(ctx context.Context, repos repository.Repositories, backup *Backup) (*RestoreResult, error) {
+	if err := repos.Pause.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete pauses: %w", err)
+	}
+	if err := repos.Tag.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete tags: %w", err)
+	}
+	if err := repos.TimeEntry.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete time entries: %w", err)
+	}
+	if err := repos.Absence.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete absences: %w", err)
+	}
+	if err := repos.Project.DeleteAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete projects: %w", err)
+	}
+
+	result := &RestoreResult{}
+
+	for _, project := range backup.Projects {
+		if _, err := repos.Project.Insert(ctx, project); err != nil {
+			return nil, fmt.Errorf("failed to restore project '%s': %w", project.Name, err)
+		}
+		result.Projects++
+	}
+
+	for _, rate := range backup.CategoryRates {
+		if err := repos.Project.SetCategoryRate(ctx, rate.ProjectID, rate.Category, rate.HourlyRate); err != nil {
+			return nil, fmt.Errorf("failed to restore category rate '%s': %w", rate.Category, err)
+		}
+	}
+
+	for _, entry := range backup.TimeEntries {
+		if _, err := insertBackupEntry(ctx, repos, entry); err != nil {
+			return nil, err
+		}
+		result.TimeEntries++
+	}
+
+	for _, pause := range backup.Pauses {
+		if _, err := repos.Pause.Insert(ctx, pause); err != nil {
+			return nil, fmt.Errorf("failed to restore pause %d: %w", pause.ID, err)
+		}
+		result.Pauses++
+	}
+
+	for _, absence := range backup.Absences {
+		if _, err := repos.Absence.Insert(ctx, absence); err != nil {
+			return nil, fmt.Errorf("failed to restore absence %d: %w", absence.ID, err)
+		}
+		result.Absences++
+	}
+
+	return result, nil
+}
+
+// mergeBackup adds the records of the backup to the existing data with new IDs. Projects are matched by name and keep
+// their settings if they exist already, time entries and absences which exist already are skipped.
+func mergeBackup(ctx context.Context, repos repository.Repositories, backup *Backup) (*RestoreResult, error) {
+	result := &RestoreResult{}
+
+	// projectIDs and entryIDs map the IDs of the backup to the IDs in the database
+	projectIDs := make(map[int]int, len(backup.Projects))
+	newProjects := make(map[int]bool)
+	for _, project := range backup.Projects {
+		existing, err := repos.Project.GetByName(ctx, project.Name)
+		if err == nil {
+			projectIDs[project.ID] = existing.ID
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get project '%s': %w", project.Name, err)
+		}
+
+		backupID := project.ID
+		project.ID = 0
+		id, err := repos.Project.Insert(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore project '%s': %w", project.Name, err)
+		}
+		projectIDs[backupID] = id
+		newProjects[backupID] = true
+		result.Projects++
+	}
+
+	for _, rate := range backup.CategoryRates {
+		if !newProjects[rate.ProjectID] {
+			continue
+		}
+		if err := repos.Project.SetCategoryRate(ctx, projectIDs[rate.ProjectID], rate.Category, rate.HourlyRate); err != nil {
+			return nil, fmt.Errorf("failed to restore category rate '%s': %w", rate.Category, err)
+		}
+	}
+
+	entryIDs := make(map[int]int, len(backup.TimeEntries))
+	for _, entry := range backup.TimeEntries {
+		backupID := entry.ID
+		entry.ID = 0
+		entry.ProjectID = projectIDs[entry.ProjectID]
+
+		_, err := repos.TimeEntry.GetByProjectAndStartTime(ctx, entry.ProjectID, entry.StartTime)
+		if err == nil {
+			result.SkippedTimeEntries++
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to check for existing time entry: %w", err)
+		}
+
+		if entry.EndTime == nil {
+			if active, err := repos.TimeEntry.GetActive(ctx); err == nil && active != nil {
+				return nil, fmt.Errorf("backup contains an active time entry while time entry %d is active", active.ID)
+			}
+		}
+
+		id, err := insertBackupEntry(ctx, repos, entry)
+		if err != nil {
+			return nil, err
+		}
+		entryIDs[backupID] = id
+		result.TimeEntries++
+	}
+
+	for _, pause := range backup.Pauses {
+		timeEntryID, ok := entryIDs[pause.TimeEntryID]
+		if !ok {
+			// The time entry of the pause was skipped
+			continue
+		}
+
+		pause.ID = 0
+		pause.TimeEntryID = timeEntryID
+		if _, err := repos.Pause.Insert(ctx, pause); err != nil {
+			return nil, fmt.Errorf("failed to restore pause: %w", err)
+		}
+		result.Pauses++
+	}
+
+	for _, absence := range backup.Absences {
+		existing, err := repos.Absence.GetInRange(ctx, &absence.Date, &absence.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absences: %w", err)
+		}
+
+		duplicate := slices.ContainsFunc(existing, func(a model.Absence) bool {
+			return a.Type == absence.Type
+		})
+		if duplicate || absenceFraction(existing)+absence.Fraction() > 1 {
+			result.SkippedAbsences++
+			continue
+		}
+
+		absence.ID = 0
+		if _, err := repos.Absence.Insert(ctx, absence); err != nil {
+			return nil, fmt.Errorf("failed to restore absence: %w", err)
+		}
+		result.Absences++
+	}
+
+	return result, nil
+}
+
+// insertBackupEntry inserts a time entry of a backup with its tags and returns its ID, a zero ID is assigned by the
+// database
+func insertBackupEntry(ctx context.Context, repos repository.Repositories, entry model.TimeEntry) (int, error) {
+	id, err := repos.TimeEntry.Insert(ctx, entry)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore time entry: %w", err)
+	}
+
+	if len(entry.Tags) > 0 {
+		if err := repos.Tag.SetForTimeEntry(ctx, id, entry.Tags); err != nil {
+			return 0, fmt.Errorf("failed to restore tags of time entry: %w", err)
+		}
+	}
+
+	return id, nil
+}
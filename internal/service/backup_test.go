@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testBackup() *Backup {
+	start := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	pauseStart := start.Add(time.Hour)
+	pauseEnd := pauseStart.Add(15 * time.Minute)
+
+	return &Backup{
+		Version:       BackupVersion,
+		Projects:      []model.Project{{ID: 3, Name: "Client"}, {ID: 5, Name: "Internal"}},
+		CategoryRates: []model.CategoryRate{{ProjectID: 5, Category: "meeting", HourlyRate: 50}},
+		TimeEntries: []model.TimeEntry{
+			{ID: 7, ProjectID: 3, StartTime: start, EndTime: &end, Tags: []string{"remote"}},
+			{ID: 8, ProjectID: 5, StartTime: end},
+		},
+		Pauses: []model.Pause{{ID: 2, TimeEntryID: 7, PauseStart: pauseStart, PauseEnd: &pauseEnd}},
+	}
+}
+
+func TestTimeTracking_CreateBackup(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	lastTracked := time.Now()
+	project := &model.Project{ID: 1, Name: "Client"}
+	mockProjectRepo.On("GetAll", ctx).Return([]model.Project{{ID: 1, Name: "Client", LastTrackedAt: &lastTracked}}, nil)
+	mockProjectRepo.On("GetCategoryRates", ctx).Return([]model.CategoryRate{}, nil)
+	mockTimeEntryRepo.On("GetAllWithPausesFiltered", ctx, -1, "asc", repository.TimeEntryFilter{}).Return([]repository.TimeEntryWithPauses{
+		{TimeEntry: model.TimeEntry{ID: 4, ProjectID: 1, Project: project}, PauseCount: 1},
+	}, nil)
+	mockPauseRepo.On("GetAll", ctx).Return([]model.Pause{{ID: 9, TimeEntryID: 4}}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, (*time.Time)(nil), (*time.Time)(nil)).Return([]model.Absence{}, nil)
+
+	backup, err := service.CreateBackup(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, BackupVersion, backup.Version)
+	require.Len(t, backup.Projects, 1)
+	assert.Nil(t, backup.Projects[0].LastTrackedAt)
+	require.Len(t, backup.TimeEntries, 1)
+	assert.Equal(t, 4, backup.TimeEntries[0].ID)
+	assert.Nil(t, backup.TimeEntries[0].Project)
+	assert.Len(t, backup.Pauses, 1)
+	assert.NoError(t, backup.Validate())
+}
+
+func TestBackup_Validate(t *testing.T) {
+	assert.NoError(t, testBackup().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(b *Backup)
+	}{
+		{"unsupported version", func(b *Backup) { b.Version = BackupVersion + 1 }},
+		{"duplicate project ID", func(b *Backup) { b.Projects[1].ID = 3 }},
+		{"duplicate project name", func(b *Backup) { b.Projects[1].Name = "Client" }},
+		{"unknown project of category rate", func(b *Backup) { b.CategoryRates[0].ProjectID = 4 }},
+		{"unknown project of time entry", func(b *Backup) { b.TimeEntries[0].ProjectID = 4 }},
+		{"several active time entries", func(b *Backup) { b.TimeEntries[0].EndTime = nil }},
+		{"unknown time entry of pause", func(b *Backup) { b.Pauses[0].TimeEntryID = 9 }},
+		{"invalid absence type", func(b *Backup) { b.Absences = []model.Absence{{ID: 1, Type: "party"}} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := testBackup()
+			tt.modify(backup)
+			assert.Error(t, backup.Validate())
+		})
+	}
+}
+
+func TestTimeTracking_RestoreBackup_Replace(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo, Absence: mockAbsenceRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+	backup := testBackup()
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockPauseRepo.On("DeleteAll", ctx).Return(nil)
+	mockTagRepo.On("DeleteAll", ctx).Return(nil)
+	mockTimeEntryRepo.On("DeleteAll", ctx).Return(nil)
+	mockAbsenceRepo.On("DeleteAll", ctx).Return(nil)
+	mockProjectRepo.On("DeleteAll", ctx).Return(nil)
+	mockProjectRepo.On("Insert", ctx, backup.Projects[0]).Return(3, nil)
+	mockProjectRepo.On("Insert", ctx, backup.Projects[1]).Return(5, nil)
+	mockProjectRepo.On("SetCategoryRate", ctx, 5, "meeting", 50.0).Return(nil)
+	mockTimeEntryRepo.On("Insert", ctx, backup.TimeEntries[0]).Return(7, nil)
+	mockTimeEntryRepo.On("Insert", ctx, backup.TimeEntries[1]).Return(8, nil)
+	mockTagRepo.On("SetForTimeEntry", ctx, 7, []string{"remote"}).Return(nil)
+	mockPauseRepo.On("Insert", ctx, backup.Pauses[0]).Return(2, nil)
+
+	result, err := service.RestoreBackup(ctx, backup, RestoreModeReplace)
+
+	require.NoError(t, err)
+	assert.Equal(t, &RestoreResult{Projects: 2, TimeEntries: 2, Pauses: 1}, result)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_RestoreBackup_Merge(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo, Absence: mockAbsenceRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+	backup := testBackup()
+
+	// Client exists already with another ID and has the first entry, Internal is new
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockProjectRepo.On("GetByName", ctx, "Client").Return(&model.Project{ID: 1, Name: "Client"}, nil)
+	mockProjectRepo.On("GetByName", ctx, "Internal").Return((*model.Project)(nil), sql.ErrNoRows)
+	mockProjectRepo.On("Insert", ctx, mock.MatchedBy(func(p model.Project) bool { return p.ID == 0 && p.Name == "Internal" })).Return(2, nil)
+	mockProjectRepo.On("SetCategoryRate", ctx, 2, "meeting", 50.0).Return(nil)
+	mockTimeEntryRepo.On("GetByProjectAndStartTime", ctx, 1, backup.TimeEntries[0].StartTime).Return(&model.TimeEntry{ID: 1}, nil)
+	mockTimeEntryRepo.On("GetByProjectAndStartTime", ctx, 2, backup.TimeEntries[1].StartTime).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
+	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
+	mockTimeEntryRepo.On("Insert", ctx, mock.MatchedBy(func(e model.TimeEntry) bool { return e.ID == 0 && e.ProjectID == 2 })).Return(11, nil)
+
+	result, err := service.RestoreBackup(ctx, backup, RestoreModeMerge)
+
+	require.NoError(t, err)
+	// The pause belongs to the skipped entry
+	assert.Equal(t, &RestoreResult{Projects: 1, TimeEntries: 1, SkippedTimeEntries: 1}, result)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertNotCalled(t, "Insert", mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_RestoreBackup_Invalid(t *testing.T) {
+	ctx := context.Background()
+	mockTransactor := &MockTransactor{}
+	service := &timeTracking{transactor: mockTransactor}
+
+	backup := testBackup()
+	backup.Pauses[0].TimeEntryID = 42
+
+	_, err := service.RestoreBackup(ctx, backup, RestoreModeReplace)
+	assert.Error(t, err)
+
+	_, err = service.RestoreBackup(ctx, testBackup(), "append")
+	assert.Error(t, err)
+
+	mockTransactor.AssertNotCalled(t, "WithinTransaction", ctx)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+// WorkSchedule defines the expected work time per weekday
+type WorkSchedule interface {
+	Expected(weekday time.Weekday) time.Duration
+}
+
+// DayBalance represents the expected and worked time of a single day
+type DayBalance struct {
+	Date     time.Time
+	Expected time.Duration
+	Worked   time.Duration
+	// Credited is the part of the expected time which counts as worked because of absences
+	Credited time.Duration
+	Absences []model.Absence
+}
+
+// Difference returns the overtime (positive) or undertime (negative) of the day
+func (d DayBalance) Difference() time.Duration {
+	return d.Worked + d.Credited - d.Expected
+}
+
+// WeekBalance represents the expected and worked time of a week
+type WeekBalance struct {
+	Start    time.Time
+	Days     []DayBalance
+	Expected time.Duration
+	Worked   time.Duration
+	Credited time.Duration
+}
+
+// Difference returns the overtime (positive) or undertime (negative) of the week
+func (w WeekBalance) Difference() time.Duration {
+	return w.Worked + w.Credited - w.Expected
+}
+
+// Balance represents the overtime balance account over a period of weeks
+type Balance struct {
+	Weeks    []WeekBalance
+	Expected time.Duration
+	Worked   time.Duration
+	Credited time.Duration
+}
+
+// Difference returns the cumulative overtime (positive) or undertime (negative) of the whole period
+func (b *Balance) Difference() time.Duration {
+	return b.Worked + b.Credited - b.Expected
+}
+
+// GetBalance compares the expected work time of the schedule with the effective work time per day from the given
+// date until the given date (both inclusive). Absences credit their part of the expected time of their day. Without a
+// since date the balance starts with the first tracked day.
+func (s *timeTracking) GetBalance(ctx context.Context, schedule WorkSchedule, weekStart time.Weekday, since *time.Time, until time.Time) (*Balance, error) {
+	until = startOfDay(until)
+
+	var from time.Time
+	if since != nil {
+		from = startOfDay(*since)
+	} else {
+		first, err := s.timeEntryRepo.GetAllWithPauses(ctx, 1, "asc", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get first time entry: %w", err)
+		}
+
+		from = until
+		if len(first) > 0 {
+			from = startOfDay(first[0].StartTime)
+		}
+	}
+
+	if from.After(until) {
+		return nil, fmt.Errorf("start date must not be after the end date")
+	}
+
+	entries, err := s.timeEntryRepo.GetAllWithPauses(ctx, -1, "asc", &from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	// Entries are accounted to the day they started
+	now := time.Now()
+	worked := make(map[string]time.Duration)
+	for _, entry := range entries {
+		day := startOfDay(entry.StartTime)
+		if day.After(until) {
+			continue
+		}
+
+		if entry.Duration != nil {
+			worked[day.Format(time.DateOnly)] += *entry.Duration
+			continue
+		}
+
+		pauses, err := s.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pauses: %w", err)
+		}
+		worked[day.Format(time.DateOnly)] += calculateActiveWorkDuration(entry.StartTime, now, pauses)
+	}
+
+	absences, err := s.absenceRepo.GetInRange(ctx, &from, &until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	absencesByDay := make(map[string][]model.Absence)
+	for _, absence := range absences {
+		key := absence.Date.Format(time.DateOnly)
+		absencesByDay[key] = append(absencesByDay[key], absence)
+	}
+
+	balance := &Balance{}
+	for day := from; !day.After(until); day = day.AddDate(0, 0, 1) {
+		weekStartDate := startOfWeek(day, weekStart)
+		if len(balance.Weeks) == 0 || !balance.Weeks[len(balance.Weeks)-1].Start.Equal(weekStartDate) {
+			balance.Weeks = append(balance.Weeks, WeekBalance{Start: weekStartDate})
+		}
+
+		dayBalance := DayBalance{
+			Date:     day,
+			Expected: schedule.Expected(day.Weekday()),
+			Worked:   worked[day.Format(time.DateOnly)],
+			Absences: absencesByDay[day.Format(time.DateOnly)],
+		}
+		dayBalance.Credited = time.Duration(absenceFraction(dayBalance.Absences) * float64(dayBalance.Expected))
+
+		week := &balance.Weeks[len(balance.Weeks)-1]
+		week.Days = append(week.Days, dayBalance)
+		week.Expected += dayBalance.Expected
+		week.Worked += dayBalance.Worked
+		week.Credited += dayBalance.Credited
+
+		balance.Expected += dayBalance.Expected
+		balance.Worked += dayBalance.Worked
+		balance.Credited += dayBalance.Credited
+	}
+
+	return balance, nil
+}
+
+// startOfDay returns the start of the day of the given time in the local timezone
+func startOfDay(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// startOfWeek returns the start of the week of the given time in the local timezone
+func startOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// weekdaySchedule is a work schedule with the same hours from Monday to Friday
+type weekdaySchedule time.Duration
+
+func (s weekdaySchedule) Expected(weekday time.Weekday) time.Duration {
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return 0
+	}
+	return time.Duration(s)
+}
+
+func TestTimeTracking_GetBalance(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	// Friday, 2025-10-17 until Tuesday, 2025-10-21
+	since := time.Date(2025, 10, 17, 0, 0, 0, 0, time.Local)
+	until := time.Date(2025, 10, 21, 18, 0, 0, 0, time.Local)
+	untilDay := time.Date(2025, 10, 21, 0, 0, 0, 0, time.Local)
+
+	newEntry := func(start time.Time, duration time.Duration) repository.TimeEntryWithPauses {
+		end := start.Add(duration)
+		return repository.TimeEntryWithPauses{TimeEntry: model.TimeEntry{StartTime: start, EndTime: &end, Duration: &duration}}
+	}
+
+	entries := []repository.TimeEntryWithPauses{
+		newEntry(since.Add(9*time.Hour), 6*time.Hour),
+		newEntry(since.Add(16*time.Hour), 3*time.Hour),
+		newEntry(since.AddDate(0, 0, 1).Add(10*time.Hour), 2*time.Hour),
+		newEntry(since.AddDate(0, 0, 3).Add(9*time.Hour), 7*time.Hour),
+		newEntry(since.AddDate(0, 0, 5).Add(9*time.Hour), 8*time.Hour),
+	}
+
+	mockTimeEntryRepo.On("GetAllWithPauses", ctx, -1, "asc", &since).Return(entries, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, &since, &untilDay).Return([]model.Absence{
+		{ID: 1, Date: since.AddDate(0, 0, 4), Type: model.AbsenceTypeVacation, HalfDay: true},
+	}, nil)
+
+	balance, err := service.GetBalance(ctx, weekdaySchedule(8*time.Hour), time.Monday, &since, until)
+
+	require.NoError(t, err)
+	require.Len(t, balance.Weeks, 2)
+
+	firstWeek := balance.Weeks[0]
+	assert.Equal(t, time.Date(2025, 10, 13, 0, 0, 0, 0, time.Local), firstWeek.Start)
+	require.Len(t, firstWeek.Days, 3)
+	assert.Equal(t, 9*time.Hour, firstWeek.Days[0].Worked)
+	assert.Equal(t, time.Hour, firstWeek.Days[0].Difference())
+	assert.Equal(t, 2*time.Hour, firstWeek.Days[1].Difference())
+	assert.Equal(t, 8*time.Hour, firstWeek.Expected)
+	assert.Equal(t, 11*time.Hour, firstWeek.Worked)
+
+	secondWeek := balance.Weeks[1]
+	assert.Equal(t, time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local), secondWeek.Start)
+	require.Len(t, secondWeek.Days, 2)
+	assert.Equal(t, -time.Hour, secondWeek.Days[0].Difference())
+	// The half day of vacation credits half of the expected time
+	assert.Equal(t, 4*time.Hour, secondWeek.Days[1].Credited)
+	assert.Len(t, secondWeek.Days[1].Absences, 1)
+	assert.Equal(t, -4*time.Hour, secondWeek.Days[1].Difference())
+
+	assert.Equal(t, 24*time.Hour, balance.Expected)
+	assert.Equal(t, 18*time.Hour, balance.Worked)
+	assert.Equal(t, 4*time.Hour, balance.Credited)
+	assert.Equal(t, -2*time.Hour, balance.Difference())
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockAbsenceRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_GetBalance_WeekStart(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	// Saturday, 2025-10-18 until Monday, 2025-10-20
+	since := time.Date(2025, 10, 18, 0, 0, 0, 0, time.Local)
+	until := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+
+	mockTimeEntryRepo.On("GetAllWithPauses", ctx, -1, "asc", &since).Return([]repository.TimeEntryWithPauses{}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, &since, &until).Return([]model.Absence{}, nil)
+
+	balance, err := service.GetBalance(ctx, weekdaySchedule(8*time.Hour), time.Sunday, &since, until)
+
+	require.NoError(t, err)
+	require.Len(t, balance.Weeks, 2)
+	assert.Equal(t, time.Date(2025, 10, 12, 0, 0, 0, 0, time.Local), balance.Weeks[0].Start)
+	assert.Equal(t, time.Date(2025, 10, 19, 0, 0, 0, 0, time.Local), balance.Weeks[1].Start)
+	assert.Len(t, balance.Weeks[1].Days, 2)
+	assert.Equal(t, -8*time.Hour, balance.Difference())
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+// defaultCurrency is used for projects which get an hourly rate without a currency
+const defaultCurrency = "EUR"
+
+// BillingRates holds the hourly rates of all projects and their category overrides
+type BillingRates struct {
+	projects      map[int]model.Project
+	categoryRates map[int]map[string]float64
+}
+
+// ProjectRevenue represents the billable time and revenue of a project within a period
+type ProjectRevenue struct {
+	Project          model.Project
+	Currency         string
+	BillableDuration time.Duration
+	// UnratedDuration is the part of BillableDuration for which neither the project nor the category has a rate
+	UnratedDuration time.Duration
+	Amount          float64
+}
+
+// CategoryRates returns the category rates which override the project rate of the given project
+func (r *BillingRates) CategoryRates(projectID int) map[string]float64 {
+	return r.categoryRates[projectID]
+}
+
+// HourlyRate returns the hourly rate and currency which apply to the given time entry. The rate of the entry's
+// category takes precedence over the rate of its project.
+func (r *BillingRates) HourlyRate(entry model.TimeEntry) (float64, string, bool) {
+	project, ok := r.projects[entry.ProjectID]
+	if !ok {
+		return 0, "", false
+	}
+
+	currency := defaultCurrency
+	if project.Currency != nil {
+		currency = *project.Currency
+	}
+
+	if entry.Category != nil {
+		if rate, ok := r.categoryRates[entry.ProjectID][*entry.Category]; ok {
+			return rate, currency, true
+		}
+	}
+
+	if project.HourlyRate == nil {
+		return 0, "", false
+	}
+
+	return *project.HourlyRate, currency, true
+}
+
+// Amount returns the billed amount and currency of the given time entry. Only completed, billable entries with an
+// hourly rate have an amount.
+func (r *BillingRates) Amount(entry model.TimeEntry) (float64, string, bool) {
+	if !entry.Billable || entry.EndTime == nil || entry.Duration == nil {
+		return 0, "", false
+	}
+
+	rate, currency, ok := r.HourlyRate(entry)
+	if !ok {
+		return 0, "", false
+	}
+
+	return roundToCents(entry.Duration.Hours() * rate), currency, true
+}
+
+// SetProjectRate sets the hourly rate of a project. An empty currency keeps the current one of the project.
+func (s *timeTracking) SetProjectRate(ctx context.Context, projectIDOrName string, hourlyRate float64, currency string) (*model.Project, error) {
+	if hourlyRate < 0 {
+		return nil, fmt.Errorf("hourly rate must not be negative")
+	}
+
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if currency == "" {
+		currency = defaultCurrency
+		if project.Currency != nil {
+			currency = *project.Currency
+		}
+	}
+
+	if err := s.projectRepo.UpdateRate(ctx, project.ID, &hourlyRate, &currency); err != nil {
+		return nil, fmt.Errorf("failed to update hourly rate: %w", err)
+	}
+
+	project.HourlyRate = &hourlyRate
+	project.Currency = &currency
+	return project, nil
+}
+
+// RemoveProjectRate removes the hourly rate and currency of a project
+func (s *timeTracking) RemoveProjectRate(ctx context.Context, projectIDOrName string) (*model.Project, error) {
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.UpdateRate(ctx, project.ID, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to remove hourly rate: %w", err)
+	}
+
+	project.HourlyRate = nil
+	project.Currency = nil
+	return project, nil
+}
+
+// SetCategoryRate sets the hourly rate which overrides the project rate for entries of a category
+func (s *timeTracking) SetCategoryRate(ctx context.Context, projectIDOrName string, category string, hourlyRate float64) (*model.Project, error) {
+	if hourlyRate < 0 {
+		return nil, fmt.Errorf("hourly rate must not be negative")
+	}
+
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.SetCategoryRate(ctx, project.ID, category, hourlyRate); err != nil {
+		return nil, fmt.Errorf("failed to set category rate: %w", err)
+	}
+
+	return project, nil
+}
+
+// RemoveCategoryRate removes the hourly rate of a category within a project
+func (s *timeTracking) RemoveCategoryRate(ctx context.Context, projectIDOrName string, category string) (*model.Project, error) {
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.DeleteCategoryRate(ctx, project.ID, category); err != nil {
+		return nil, fmt.Errorf("failed to remove category rate: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetBillingRates returns the hourly rates of all projects and their category overrides
+func (s *timeTracking) GetBillingRates(ctx context.Context) (*BillingRates, error) {
+	projects, err := s.projectRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	categoryRates, err := s.projectRepo.GetCategoryRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category rates: %w", err)
+	}
+
+	rates := &BillingRates{
+		projects:      make(map[int]model.Project),
+		categoryRates: make(map[int]map[string]float64),
+	}
+
+	for _, project := range projects {
+		rates.projects[project.ID] = project
+	}
+
+	for _, rate := range categoryRates {
+		if rates.categoryRates[rate.ProjectID] == nil {
+			rates.categoryRates[rate.ProjectID] = make(map[string]float64)
+		}
+		rates.categoryRates[rate.ProjectID][rate.Category] = rate.HourlyRate
+	}
+
+	return rates, nil
+}
+
+// GetRevenueReport returns the billable time and revenue per project of all completed entries which started within
+// the given period. Projects without billable time are omitted.
+func (s *timeTracking) GetRevenueReport(ctx context.Context, since *time.Time, until *time.Time) ([]ProjectRevenue, error) {
+	rates, err := s.GetBillingRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.GetAllWithPauses(ctx, -1, "asc", since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	revenues := make(map[int]*ProjectRevenue)
+	for _, entry := range entries {
+		if until != nil && !entry.StartTime.Before(*until) {
+			continue
+		}
+
+		if !entry.Billable || entry.EndTime == nil || entry.Duration == nil {
+			continue
+		}
+
+		revenue, ok := revenues[entry.ProjectID]
+		if !ok {
+			project, ok := rates.projects[entry.ProjectID]
+			if !ok && entry.Project != nil {
+				project = *entry.Project
+			}
+			revenue = &ProjectRevenue{Project: project, Currency: defaultCurrency}
+			if project.Currency != nil {
+				revenue.Currency = *project.Currency
+			}
+			revenues[entry.ProjectID] = revenue
+		}
+
+		revenue.BillableDuration += *entry.Duration
+
+		amount, _, ok := rates.Amount(entry.TimeEntry)
+		if !ok {
+			revenue.UnratedDuration += *entry.Duration
+			continue
+		}
+		revenue.Amount = roundToCents(revenue.Amount + amount)
+	}
+
+	report := make([]ProjectRevenue, 0, len(revenues))
+	for _, revenue := range revenues {
+		report = append(report, *revenue)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Project.Name < report[j].Project.Name
+	})
+
+	return report, nil
+}
+
+// roundToCents rounds the given amount to two decimal places
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_GetRevenueReport(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+	}
+
+	rate := 80.0
+	usd := "USD"
+	meeting := "meeting"
+	clientProject := model.Project{ID: 1, Name: "Client", HourlyRate: &rate, Currency: &usd}
+	internalProject := model.Project{ID: 2, Name: "Internal"}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	until := time.Date(2025, 10, 18, 0, 0, 0, 0, time.Local)
+	newEntry := func(id int, projectID int, start time.Time, duration time.Duration, category *string, billable bool) repository.TimeEntryWithPauses {
+		end := start.Add(duration)
+		return repository.TimeEntryWithPauses{TimeEntry: model.TimeEntry{
+			ID: id, ProjectID: projectID, StartTime: start, EndTime: &end, Duration: &duration, Category: category, Billable: billable,
+		}}
+	}
+
+	entries := []repository.TimeEntryWithPauses{
+		newEntry(1, 1, startTime, 2*time.Hour, nil, true),
+		newEntry(2, 1, startTime.Add(3*time.Hour), 30*time.Minute, &meeting, true),
+		newEntry(3, 1, startTime.Add(4*time.Hour), time.Hour, nil, false),
+		newEntry(4, 2, startTime.Add(5*time.Hour), time.Hour, nil, true),
+		newEntry(5, 1, until, time.Hour, nil, true),
+	}
+
+	mockProjectRepo.On("GetAll", ctx).Return([]model.Project{clientProject, internalProject}, nil)
+	mockProjectRepo.On("GetCategoryRates", ctx).Return([]model.CategoryRate{{ProjectID: 1, Category: "meeting", HourlyRate: 60}}, nil)
+	mockTimeEntryRepo.On("GetAllWithPauses", ctx, -1, "asc", mock.Anything).Return(entries, nil)
+
+	report, err := service.GetRevenueReport(ctx, &startTime, &until)
+
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+
+	assert.Equal(t, "Client", report[0].Project.Name)
+	assert.Equal(t, "USD", report[0].Currency)
+	assert.Equal(t, 150*time.Minute, report[0].BillableDuration)
+	assert.Equal(t, 190.0, report[0].Amount)
+	assert.Zero(t, report[0].UnratedDuration)
+
+	assert.Equal(t, "Internal", report[1].Project.Name)
+	assert.Equal(t, time.Hour, report[1].UnratedDuration)
+	assert.Zero(t, report[1].Amount)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectRate(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+
+	service := &timeTracking{
+		projectRepo: mockProjectRepo,
+	}
+
+	currency := "CHF"
+	project := &model.Project{ID: 1, Name: "Client", Currency: &currency}
+	rate := 95.5
+
+	mockProjectRepo.On("GetByIDOrName", ctx, "Client").Return(project, nil)
+	mockProjectRepo.On("UpdateRate", ctx, 1, &rate, &currency).Return(nil)
+
+	result, err := service.SetProjectRate(ctx, "Client", rate, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 95.5, *result.HourlyRate)
+	assert.Equal(t, "CHF", *result.Currency)
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectRate_Negative(t *testing.T) {
+	service := &timeTracking{}
+
+	result, err := service.SetProjectRate(context.Background(), "Client", -1, "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+// BudgetStatus represents the used and remaining time budget of a project within its current budget period
+type BudgetStatus struct {
+	Project model.Project
+	Budget  time.Duration
+	Period  string
+	// PeriodStart is the start of the current budget period, nil for budgets covering the whole project
+	PeriodStart *time.Time
+	// Used includes the work time of the active entry if it belongs to the project
+	Used time.Duration
+}
+
+// Remaining returns the remaining time budget, which is negative once the budget is exceeded
+func (b *BudgetStatus) Remaining() time.Duration {
+	return b.Budget - b.Used
+}
+
+// UsedRatio returns the used part of the budget, e.g. 0.8 for 80%
+func (b *BudgetStatus) UsedRatio() float64 {
+	if b.Budget <= 0 {
+		return 0
+	}
+
+	return float64(b.Used) / float64(b.Budget)
+}
+
+// Exceeded returns true if the whole budget is used up
+func (b *BudgetStatus) Exceeded() bool {
+	return b.Used >= b.Budget
+}
+
+// SetProjectBudget sets the time budget of a project for the given period
+func (s *timeTracking) SetProjectBudget(ctx context.Context, projectIDOrName string, budget time.Duration, period string) (*model.Project, error) {
+	if budget <= 0 {
+		return nil, fmt.Errorf("budget must be greater than zero")
+	}
+
+	if period != model.BudgetPeriodTotal && period != model.BudgetPeriodMonth {
+		return nil, fmt.Errorf("invalid budget period '%s', must be '%s' or '%s'", period, model.BudgetPeriodTotal, model.BudgetPeriodMonth)
+	}
+
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.UpdateBudget(ctx, project.ID, &budget, &period); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	project.Budget = &budget
+	project.BudgetPeriod = &period
+	return project, nil
+}
+
+// RemoveProjectBudget removes the time budget of a project
+func (s *timeTracking) RemoveProjectBudget(ctx context.Context, projectIDOrName string) (*model.Project, error) {
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.UpdateBudget(ctx, project.ID, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to remove budget: %w", err)
+	}
+
+	project.Budget = nil
+	project.BudgetPeriod = nil
+	return project, nil
+}
+
+// GetBudgetStatus returns the budget status of a project at the given time. It returns nil if the project has no
+// budget.
+func (s *timeTracking) GetBudgetStatus(ctx context.Context, projectIDOrName string, at time.Time) (*BudgetStatus, error) {
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if project.Budget == nil {
+		return nil, nil
+	}
+
+	status := &BudgetStatus{
+		Project: *project,
+		Budget:  *project.Budget,
+		Period:  model.BudgetPeriodTotal,
+	}
+
+	if project.BudgetPeriod != nil && *project.BudgetPeriod == model.BudgetPeriodMonth {
+		status.Period = model.BudgetPeriodMonth
+		local := at.Local()
+		periodStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, time.Local)
+		status.PeriodStart = &periodStart
+	}
+
+	status.Used, err = s.timeEntryRepo.GetWorkTimeByProject(ctx, project.ID, status.PeriodStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work time: %w", err)
+	}
+
+	// Add the time of the running session, which is not completed yet
+	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
+	if err == nil && activeEntry != nil && activeEntry.ProjectID == project.ID {
+		if status.PeriodStart == nil || !activeEntry.StartTime.Before(*status.PeriodStart) {
+			pauses, err := s.pauseRepo.GetByTimeEntry(ctx, activeEntry.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pauses: %w", err)
+			}
+
+			status.Used += calculateActiveWorkDuration(activeEntry.StartTime, at, pauses)
+		}
+	}
+
+	return status, nil
+}
+
+// calculateActiveWorkDuration calculates the work duration of a running time entry up to the given time, taking a
+// currently active pause into account
+func calculateActiveWorkDuration(startTime time.Time, at time.Time, pauses []model.Pause) time.Duration {
+	workDuration := calculateWorkDuration(startTime, at, pauses)
+	for _, pause := range pauses {
+		if pause.PauseEnd == nil && pause.PauseStart.Before(at) {
+			workDuration -= at.Sub(pause.PauseStart)
+		}
+	}
+
+	if workDuration < 0 {
+		return 0
+	}
+
+	return workDuration
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_GetBudgetStatus(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	budget := 10 * time.Hour
+	period := model.BudgetPeriodMonth
+	project := &model.Project{ID: 1, Name: "Fixed Price", Budget: &budget, BudgetPeriod: &period}
+
+	now := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	periodStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.Local)
+
+	// The active entry started two hours ago and is paused since 30 minutes
+	pauseStart := now.Add(-30 * time.Minute)
+	activeEntry := &model.TimeEntry{ID: 5, ProjectID: 1, StartTime: now.Add(-2 * time.Hour)}
+
+	mockProjectRepo.On("GetByIDOrName", ctx, "Fixed Price").Return(project, nil)
+	mockTimeEntryRepo.On("GetWorkTimeByProject", ctx, 1, &periodStart).Return(7*time.Hour, nil)
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 5).Return([]model.Pause{{ID: 1, TimeEntryID: 5, PauseStart: pauseStart}}, nil)
+
+	status, err := service.GetBudgetStatus(ctx, "Fixed Price", now)
+
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, model.BudgetPeriodMonth, status.Period)
+	assert.Equal(t, periodStart, *status.PeriodStart)
+	assert.Equal(t, 8*time.Hour+30*time.Minute, status.Used)
+	assert.Equal(t, time.Hour+30*time.Minute, status.Remaining())
+	assert.InDelta(t, 0.85, status.UsedRatio(), 0.0001)
+	assert.False(t, status.Exceeded())
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_GetBudgetStatus_NoBudget(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+
+	service := &timeTracking{
+		projectRepo: mockProjectRepo,
+	}
+
+	mockProjectRepo.On("GetByIDOrName", ctx, "Open").Return(&model.Project{ID: 2, Name: "Open"}, nil)
+
+	status, err := service.GetBudgetStatus(ctx, "Open", time.Now())
+
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectBudget(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+
+	service := &timeTracking{
+		projectRepo: mockProjectRepo,
+	}
+
+	budget := 40 * time.Hour
+	period := model.BudgetPeriodTotal
+
+	mockProjectRepo.On("GetByIDOrName", ctx, "Fixed Price").Return(&model.Project{ID: 1, Name: "Fixed Price"}, nil)
+	mockProjectRepo.On("UpdateBudget", ctx, 1, &budget, &period).Return(nil)
+
+	project, err := service.SetProjectBudget(ctx, "Fixed Price", budget, period)
+
+	require.NoError(t, err)
+	assert.Equal(t, budget, *project.Budget)
+	assert.Equal(t, period, *project.BudgetPeriod)
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectBudget_InvalidPeriod(t *testing.T) {
+	service := &timeTracking{}
+
+	project, err := service.SetProjectBudget(context.Background(), "Fixed Price", time.Hour, "week")
+
+	assert.Error(t, err)
+	assert.Nil(t, project)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nitschmann/hora/internal/config"
+	"github.com/nitschmann/hora/internal/model"
+)
+
+const (
+	// ComplianceRuleBreak is violated if the breaks of a day are shorter than required for its work time
+	ComplianceRuleBreak = "break"
+	// ComplianceRuleRest is violated if the rest between two working days is too short
+	ComplianceRuleRest = "rest"
+	// ComplianceRuleMaxWork is violated if the work time of a day exceeds the daily maximum
+	ComplianceRuleMaxWork = "max_work"
+)
+
+// BreakRule requires a minimum total break once the work time of a day exceeds a threshold
+type BreakRule struct {
+	After   time.Duration
+	Minimum time.Duration
+}
+
+// ComplianceRules defines the statutory working time rules a day is checked against
+type ComplianceRules struct {
+	Name   string
+	Breaks []BreakRule
+	// MinBreak is the shortest interruption of work which counts as a break
+	MinBreak time.Duration
+	// MinRest is the minimum rest between the end of a working day and the start of the next one
+	MinRest time.Duration
+	// MaxDailyWork is the maximum work time of a day, zero means no limit
+	MaxDailyWork time.Duration
+}
+
+// ComplianceRuleSets holds the predefined rule sets by their name
+var ComplianceRuleSets = map[string]ComplianceRules{
+	// German Working Hours Act (ArbZG)
+	"de": {
+		Name: "de",
+		Breaks: []BreakRule{
+			{After: 6 * time.Hour, Minimum: 30 * time.Minute},
+			{After: 9 * time.Hour, Minimum: 45 * time.Minute},
+		},
+		MinBreak:     15 * time.Minute,
+		MinRest:      11 * time.Hour,
+		MaxDailyWork: 10 * time.Hour,
+	},
+	// Austrian Working Hours Act (AZG)
+	"at": {
+		Name: "at",
+		Breaks: []BreakRule{
+			{After: 6 * time.Hour, Minimum: 30 * time.Minute},
+		},
+		MinBreak:     10 * time.Minute,
+		MinRest:      11 * time.Hour,
+		MaxDailyWork: 12 * time.Hour,
+	},
+}
+
+// GetComplianceRules returns the predefined rule set with the given name
+func GetComplianceRules(name string) (ComplianceRules, error) {
+	rules, ok := ComplianceRuleSets[name]
+	if !ok {
+		names := make([]string, 0, len(ComplianceRuleSets))
+		for name := range ComplianceRuleSets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return ComplianceRules{}, fmt.Errorf("unknown compliance rules '%s', must be one of %v", name, names)
+	}
+
+	return rules, nil
+}
+
+// NewComplianceRules returns the predefined rule set with the given name with the thresholds which are set in the
+// compliance section of the configuration
+func NewComplianceRules(name string, compliance config.Compliance) (ComplianceRules, error) {
+	rules, err := GetComplianceRules(name)
+	if err != nil {
+		return rules, err
+	}
+
+	overrides := ComplianceRules{
+		MinBreak:     time.Duration(compliance.MinBreak) * time.Minute,
+		MinRest:      time.Duration(compliance.MinRest) * time.Minute,
+		MaxDailyWork: time.Duration(compliance.MaxDailyWork) * time.Minute,
+	}
+	for _, rule := range compliance.Breaks {
+		overrides.Breaks = append(overrides.Breaks, BreakRule{
+			After:   time.Duration(rule.After) * time.Minute,
+			Minimum: time.Duration(rule.Minimum) * time.Minute,
+		})
+	}
+
+	return rules.WithOverrides(overrides), nil
+}
+
+// WithOverrides returns the rule set with the thresholds which are set in overrides, zero values and no break rules
+// keep the thresholds of the rule set
+func (r ComplianceRules) WithOverrides(overrides ComplianceRules) ComplianceRules {
+	if len(overrides.Breaks) > 0 {
+		r.Breaks = overrides.Breaks
+	}
+	if overrides.MinBreak > 0 {
+		r.MinBreak = overrides.MinBreak
+	}
+	if overrides.MinRest > 0 {
+		r.MinRest = overrides.MinRest
+	}
+	if overrides.MaxDailyWork > 0 {
+		r.MaxDailyWork = overrides.MaxDailyWork
+	}
+
+	return r
+}
+
+// RequiredBreak returns the minimum total break for the given work time of a day
+func (r ComplianceRules) RequiredBreak(worked time.Duration) time.Duration {
+	var required time.Duration
+	for _, rule := range r.Breaks {
+		if worked > rule.After && rule.Minimum > required {
+			required = rule.Minimum
+		}
+	}
+
+	return required
+}
+
+// ComplianceViolation represents a violated rule on a day
+type ComplianceViolation struct {
+	Date time.Time
+	Rule string
+	// Worked is the work time of the day
+	Worked time.Duration
+	// Actual is the break, rest or work time of the day which violates the rule
+	Actual time.Duration
+	// Required is the minimum break or rest respectively the maximum work time of the rule
+	Required time.Duration
+}
+
+// complianceDay holds the tracked work of a single day
+type complianceDay struct {
+	date   time.Time
+	start  time.Time
+	end    time.Time
+	worked time.Duration
+	breaks time.Duration
+}
+
+// CheckCompliance checks the tracked work of each day from the given date until the given time (both inclusive)
+// against the rules and returns the violations ordered by date. Entries are accounted to the day they started, gaps
+// between the entries of a day count as breaks. Without a since date all tracked days are checked.
+func (s *timeTracking) CheckCompliance(ctx context.Context, rules ComplianceRules, since *time.Time, until time.Time) ([]ComplianceViolation, error) {
+	untilDay := startOfDay(until)
+
+	// The rest rule of the first day depends on the end of the previous day
+	var fetchSince *time.Time
+	if since != nil {
+		from := startOfDay(*since).AddDate(0, 0, -1)
+		fetchSince = &from
+	}
+
+	entries, err := s.timeEntryRepo.GetAllWithPauses(ctx, -1, "asc", fetchSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	var days []*complianceDay
+	var lastEnd time.Time
+	for _, entry := range entries {
+		date := startOfDay(entry.StartTime)
+		if date.After(untilDay) {
+			break
+		}
+
+		var pauses []model.Pause
+		if entry.PauseCount > 0 || entry.EndTime == nil {
+			pauses, err = s.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pauses: %w", err)
+			}
+		}
+
+		end := until
+		worked := calculateActiveWorkDuration(entry.StartTime, until, pauses)
+		if entry.EndTime != nil {
+			end = *entry.EndTime
+			worked = *entry.Duration
+		}
+
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, &complianceDay{date: date, start: entry.StartTime})
+		} else if gap := entry.StartTime.Sub(lastEnd); gap >= rules.MinBreak {
+			days[len(days)-1].breaks += gap
+		}
+
+		day := days[len(days)-1]
+		day.worked += worked
+		day.breaks += countedPauseTime(pauses, until, rules.MinBreak)
+		if end.After(day.end) {
+			day.end = end
+		}
+		lastEnd = end
+	}
+
+	var violations []ComplianceViolation
+	for i, day := range days {
+		if since != nil && day.date.Before(startOfDay(*since)) {
+			continue
+		}
+
+		if i > 0 {
+			rest := day.start.Sub(days[i-1].end)
+			if rest < rules.MinRest {
+				violations = append(violations, ComplianceViolation{
+					Date:     day.date,
+					Rule:     ComplianceRuleRest,
+					Worked:   day.worked,
+					Actual:   max(rest, 0),
+					Required: rules.MinRest,
+				})
+			}
+		}
+
+		if required := rules.RequiredBreak(day.worked); day.breaks < required {
+			violations = append(violations, ComplianceViolation{
+				Date:     day.date,
+				Rule:     ComplianceRuleBreak,
+				Worked:   day.worked,
+				Actual:   day.breaks,
+				Required: required,
+			})
+		}
+
+		if rules.MaxDailyWork > 0 && day.worked > rules.MaxDailyWork {
+			violations = append(violations, ComplianceViolation{
+				Date:     day.date,
+				Rule:     ComplianceRuleMaxWork,
+				Worked:   day.worked,
+				Actual:   day.worked,
+				Required: rules.MaxDailyWork,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// countedPauseTime returns the total time of the pauses which are long enough to count as a break. An active pause
+// counts until the given time.
+func countedPauseTime(pauses []model.Pause, at time.Time, minBreak time.Duration) time.Duration {
+	var total time.Duration
+	for _, pause := range pauses {
+		duration := at.Sub(pause.PauseStart)
+		if pause.Duration != nil {
+			duration = *pause.Duration
+		}
+
+		if duration >= minBreak {
+			total += duration
+		}
+	}
+
+	return total
+}
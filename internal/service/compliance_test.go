@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/config"
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_CheckCompliance(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	// Monday, 2025-10-20 until Wednesday, 2025-10-22
+	since := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	until := time.Date(2025, 10, 22, 23, 0, 0, 0, time.Local)
+	fetchSince := since.AddDate(0, 0, -1)
+
+	newEntry := func(id int, start time.Time, end time.Time, pauseTime time.Duration, pauseCount int) repository.TimeEntryWithPauses {
+		duration := end.Sub(start) - pauseTime
+		return repository.TimeEntryWithPauses{
+			TimeEntry:  model.TimeEntry{ID: id, StartTime: start, EndTime: &end, Duration: &duration},
+			PauseCount: pauseCount,
+			PauseTime:  pauseTime,
+		}
+	}
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2025, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	newPause := func(start time.Time, duration time.Duration) model.Pause {
+		end := start.Add(duration)
+		return model.Pause{PauseStart: start, PauseEnd: &end, Duration: &duration}
+	}
+
+	entries := []repository.TimeEntryWithPauses{
+		// Sunday evening leaves only 9 hours of rest until Monday
+		newEntry(1, at(19, 18, 0), at(19, 23, 0), 0, 0),
+		// Monday: 7 hours without a break
+		newEntry(2, at(20, 8, 0), at(20, 15, 0), 0, 0),
+		// Tuesday: 8.5 hours with a 30 minute gap between the entries
+		newEntry(3, at(21, 8, 0), at(21, 12, 0), 0, 0),
+		newEntry(4, at(21, 12, 30), at(21, 17, 0), 0, 0),
+		// Wednesday: 11:10 hours with a 40 minute and a too short 10 minute pause
+		newEntry(5, at(22, 7, 0), at(22, 19, 0), 50*time.Minute, 2),
+	}
+
+	mockTimeEntryRepo.On("GetAllWithPauses", ctx, -1, "asc", &fetchSince).Return(entries, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 5).Return([]model.Pause{
+		newPause(at(22, 10, 0), 10*time.Minute),
+		newPause(at(22, 12, 0), 40*time.Minute),
+	}, nil)
+
+	violations, err := service.CheckCompliance(ctx, ComplianceRuleSets["de"], &since, until)
+
+	require.NoError(t, err)
+	require.Len(t, violations, 4)
+
+	assert.Equal(t, since, violations[0].Date)
+	assert.Equal(t, ComplianceRuleRest, violations[0].Rule)
+	assert.Equal(t, 9*time.Hour, violations[0].Actual)
+	assert.Equal(t, 11*time.Hour, violations[0].Required)
+
+	assert.Equal(t, since, violations[1].Date)
+	assert.Equal(t, ComplianceRuleBreak, violations[1].Rule)
+	assert.Equal(t, 7*time.Hour, violations[1].Worked)
+	assert.Zero(t, violations[1].Actual)
+	assert.Equal(t, 30*time.Minute, violations[1].Required)
+
+	wednesday := since.AddDate(0, 0, 2)
+	assert.Equal(t, wednesday, violations[2].Date)
+	assert.Equal(t, ComplianceRuleBreak, violations[2].Rule)
+	assert.Equal(t, 40*time.Minute, violations[2].Actual)
+	assert.Equal(t, 45*time.Minute, violations[2].Required)
+
+	assert.Equal(t, wednesday, violations[3].Date)
+	assert.Equal(t, ComplianceRuleMaxWork, violations[3].Rule)
+	assert.Equal(t, 11*time.Hour+10*time.Minute, violations[3].Actual)
+	assert.Equal(t, 10*time.Hour, violations[3].Required)
+
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_CheckCompliance_ActiveEntry(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	since := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	fetchSince := since.AddDate(0, 0, -1)
+	start := since.Add(8 * time.Hour)
+	now := start.Add(6*time.Hour + 40*time.Minute)
+
+	mockTimeEntryRepo.On("GetAllWithPauses", ctx, -1, "asc", &fetchSince).Return([]repository.TimeEntryWithPauses{
+		{TimeEntry: model.TimeEntry{ID: 1, StartTime: start}},
+	}, nil)
+	// The active pause counts as a break until now
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{
+		{PauseStart: now.Add(-20 * time.Minute)},
+	}, nil)
+
+	violations, err := service.CheckCompliance(ctx, ComplianceRuleSets["de"], &since, now)
+
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ComplianceRuleBreak, violations[0].Rule)
+	assert.Equal(t, 6*time.Hour+20*time.Minute, violations[0].Worked)
+	assert.Equal(t, 20*time.Minute, violations[0].Actual)
+}
+
+func TestComplianceRules_RequiredBreak(t *testing.T) {
+	rules := ComplianceRuleSets["de"]
+
+	assert.Zero(t, rules.RequiredBreak(6*time.Hour))
+	assert.Equal(t, 30*time.Minute, rules.RequiredBreak(6*time.Hour+time.Minute))
+	assert.Equal(t, 30*time.Minute, rules.RequiredBreak(9*time.Hour))
+	assert.Equal(t, 45*time.Minute, rules.RequiredBreak(9*time.Hour+time.Minute))
+}
+
+func TestGetComplianceRules(t *testing.T) {
+	rules, err := GetComplianceRules("de")
+	require.NoError(t, err)
+	assert.Equal(t, "de", rules.Name)
+
+	_, err = GetComplianceRules("xx")
+	assert.Error(t, err)
+}
+
+func TestComplianceRules_WithOverrides(t *testing.T) {
+	rules := ComplianceRuleSets["de"].WithOverrides(ComplianceRules{
+		Breaks:       []BreakRule{{After: 5 * time.Hour, Minimum: 20 * time.Minute}},
+		MaxDailyWork: 8 * time.Hour,
+	})
+
+	assert.Equal(t, "de", rules.Name)
+	assert.Equal(t, []BreakRule{{After: 5 * time.Hour, Minimum: 20 * time.Minute}}, rules.Breaks)
+	assert.Equal(t, 15*time.Minute, rules.MinBreak)
+	assert.Equal(t, 11*time.Hour, rules.MinRest)
+	assert.Equal(t, 8*time.Hour, rules.MaxDailyWork)
+
+	// The predefined rule set is not changed
+	assert.Len(t, ComplianceRuleSets["de"].Breaks, 2)
+	assert.Equal(t, 10*time.Hour, ComplianceRuleSets["de"].MaxDailyWork)
+}
+
+func TestNewComplianceRules(t *testing.T) {
+	rules, err := NewComplianceRules("at", config.Compliance{
+		Breaks:  []config.ComplianceBreak{{After: 300, Minimum: 20}},
+		MinRest: 600,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "at", rules.Name)
+	assert.Equal(t, []BreakRule{{After: 5 * time.Hour, Minimum: 20 * time.Minute}}, rules.Breaks)
+	assert.Equal(t, 10*time.Minute, rules.MinBreak)
+	assert.Equal(t, 10*time.Hour, rules.MinRest)
+	assert.Equal(t, 12*time.Hour, rules.MaxDailyWork)
+
+	_, err = NewComplianceRules("xx", config.Compliance{})
+	assert.Error(t, err)
+}
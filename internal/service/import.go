@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// ImportEntry is a completed time entry to import, its project is referenced by name and created if it does not exist
+type ImportEntry struct {
+	ProjectName string
+	StartTime   time.Time
+	EndTime     time.Time
+	Attributes  EntryAttributes
+	Pauses      []model.Pause
+}
+
+// ImportProjectSummary summarizes the imported entries of a project
+type ImportProjectSummary struct {
+	Name       string
+	New        bool
+	Entries    int
+	Duplicates int
+	WorkTime   time.Duration
+}
+
+// ImportResult summarizes an import, the projects are in the order they first appear in the imported entries
+type ImportResult struct {
+	Entries    int
+	Duplicates int
+	WorkTime   time.Duration
+	Projects   []ImportProjectSummary
+}
+
+// ImportEntries adds the given time entries within a single transaction. Entries of the same project and start time
+// as an existing or an earlier imported entry are skipped as duplicates, so importing a file twice has no effect.
+// Overlaps with other entries are not checked, as the imported history may contain them. With dryRun nothing is
+// written and the result summarizes what would be imported.
+func (s *timeTracking) ImportEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error) {
+	var result *ImportResult
+
+	err := s.transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		result = &ImportResult{}
+		projects := make(map[string]*model.Project)
+		summaries := make(map[string]int)
+		seen := make(map[string]bool)
+
+		for i, entry := range entries {
+			if entry.ProjectName == "" {
+				return fmt.Errorf("entry %d has no project", i+1)
+			}
+
+			if entry.EndTime.Before(entry.StartTime) {
+				return fmt.Errorf("entry %d: end time must not be before start time", i+1)
+			}
+
+			pauses, err := validatePauses(entry.StartTime, entry.EndTime, entry.Pauses)
+			if err != nil {
+				return fmt.Errorf("entry %d: %w", i+1, err)
+			}
+
+			proj, known := projects[entry.ProjectName]
+			if !known {
+				proj, err = repos.Project.GetByName(ctx, entry.ProjectName)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("failed to get project '%s': %w", entry.ProjectName, err)
+				}
+				projects[entry.ProjectName] = proj
+
+				summaries[entry.ProjectName] = len(result.Projects)
+				result.Projects = append(result.Projects, ImportProjectSummary{Name: entry.ProjectName, New: proj == nil})
+			}
+			summary := &result.Projects[summaries[entry.ProjectName]]
+
+			key := fmt.Sprintf("%s|%d", entry.ProjectName, entry.StartTime.UnixNano())
+			duplicate := seen[key]
+			if !duplicate && proj != nil {
+				_, err := repos.TimeEntry.GetByProjectAndStartTime(ctx, proj.ID, entry.StartTime)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("failed to check for existing time entry: %w", err)
+				}
+				duplicate = err == nil
+			}
+			seen[key] = true
+
+			if duplicate {
+				summary.Duplicates++
+				result.Duplicates++
+				continue
+			}
+
+			workDuration := calculateWorkDuration(entry.StartTime, entry.EndTime, pauses)
+			summary.Entries++
+			summary.WorkTime += workDuration
+			result.Entries++
+			result.WorkTime += workDuration
+
+			if dryRun {
+				continue
+			}
+
+			if proj == nil {
+				proj, err = repos.Project.GetOrCreate(ctx, entry.ProjectName)
+				if err != nil {
+					return fmt.Errorf("failed to create project '%s': %w", entry.ProjectName, err)
+				}
+				projects[entry.ProjectName] = proj
+			}
+
+			attrs := entry.Attributes
+			created, err := repos.TimeEntry.CreateCompleted(ctx, proj.ID, entry.StartTime, entry.EndTime, workDuration, attrs.Category, attrs.Notes, !attrs.NonBillable)
+			if err != nil {
+				return fmt.Errorf("failed to create time entry: %w", err)
+			}
+
+			for _, pause := range pauses {
+				if _, err := repos.Pause.CreateCompleted(ctx, created.ID, pause.PauseStart, *pause.PauseEnd, *pause.Duration); err != nil {
+					return fmt.Errorf("failed to create pause: %w", err)
+				}
+			}
+
+			if len(attrs.Tags) > 0 {
+				if err := repos.Tag.SetForTimeEntry(ctx, created.ID, attrs.Tags); err != nil {
+					return fmt.Errorf("failed to set tags: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
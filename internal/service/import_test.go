@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_ImportEntries(t *testing.T) {
+	start := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	pauseStart := start.Add(time.Hour)
+	pauseEnd := pauseStart.Add(30 * time.Minute)
+	meeting := "meeting"
+
+	entries := []ImportEntry{
+		// Exists already
+		{ProjectName: "Client", StartTime: start, EndTime: start.Add(time.Hour)},
+		{ProjectName: "Client", StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Attributes: EntryAttributes{Tags: []string{"remote"}}},
+		{ProjectName: "Website", StartTime: start, EndTime: start.Add(2 * time.Hour), Attributes: EntryAttributes{Category: &meeting}, Pauses: []model.Pause{{PauseStart: pauseStart, PauseEnd: &pauseEnd}}},
+		// Duplicate within the imported entries
+		{ProjectName: "Website", StartTime: start, EndTime: start.Add(2 * time.Hour)},
+	}
+
+	setup := func() (*timeTracking, *MockProjectRepo, *MockTimeEntryRepo, *MockPauseRepo, *MockTagRepo) {
+		mockProjectRepo := &MockProjectRepo{}
+		mockTimeEntryRepo := &MockTimeEntryRepo{}
+		mockPauseRepo := &MockPauseRepo{}
+		mockTagRepo := &MockTagRepo{}
+		mockTransactor := &MockTransactor{
+			repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo},
+		}
+		mockTransactor.On("WithinTransaction", mock.Anything).Return()
+
+		client := &model.Project{ID: 1, Name: "Client"}
+		mockProjectRepo.On("GetByName", mock.Anything, "Client").Return(client, nil)
+		mockProjectRepo.On("GetByName", mock.Anything, "Website").Return((*model.Project)(nil), sql.ErrNoRows)
+		mockTimeEntryRepo.On("GetByProjectAndStartTime", mock.Anything, 1, start).Return(&model.TimeEntry{ID: 5}, nil)
+		mockTimeEntryRepo.On("GetByProjectAndStartTime", mock.Anything, 1, start.Add(2*time.Hour)).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
+
+		return &timeTracking{transactor: mockTransactor}, mockProjectRepo, mockTimeEntryRepo, mockPauseRepo, mockTagRepo
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		ctx := context.Background()
+		service, mockProjectRepo, mockTimeEntryRepo, _, _ := setup()
+
+		result, err := service.ImportEntries(ctx, entries, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Entries)
+		assert.Equal(t, 2, result.Duplicates)
+		assert.Equal(t, 2*time.Hour+30*time.Minute, result.WorkTime)
+		assert.Equal(t, []ImportProjectSummary{
+			{Name: "Client", Entries: 1, Duplicates: 1, WorkTime: time.Hour},
+			{Name: "Website", New: true, Entries: 1, Duplicates: 1, WorkTime: 90 * time.Minute},
+		}, result.Projects)
+		mockProjectRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+		mockTimeEntryRepo.AssertNotCalled(t, "CreateCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		ctx := context.Background()
+		service, mockProjectRepo, mockTimeEntryRepo, mockPauseRepo, mockTagRepo := setup()
+
+		mockProjectRepo.On("GetOrCreate", ctx, "Website").Return(&model.Project{ID: 2, Name: "Website"}, nil)
+		mockTimeEntryRepo.On("CreateCompleted", ctx, 1, start.Add(2*time.Hour), start.Add(3*time.Hour), time.Hour, (*string)(nil), (*string)(nil), true).Return(&model.TimeEntry{ID: 10}, nil)
+		mockTimeEntryRepo.On("CreateCompleted", ctx, 2, start, start.Add(2*time.Hour), 90*time.Minute, &meeting, (*string)(nil), true).Return(&model.TimeEntry{ID: 11}, nil)
+		mockTagRepo.On("SetForTimeEntry", ctx, 10, []string{"remote"}).Return(nil)
+		mockPauseRepo.On("CreateCompleted", ctx, 11, pauseStart, pauseEnd, 30*time.Minute).Return(&model.Pause{ID: 1}, nil)
+
+		result, err := service.ImportEntries(ctx, entries, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Entries)
+		mockProjectRepo.AssertExpectations(t)
+		mockTimeEntryRepo.AssertExpectations(t)
+		mockPauseRepo.AssertExpectations(t)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		ctx := context.Background()
+		service, _, _, _, _ := setup()
+
+		_, err := service.ImportEntries(ctx, []ImportEntry{{StartTime: start, EndTime: start.Add(time.Hour)}}, false)
+		assert.ErrorContains(t, err, "no project")
+
+		_, err = service.ImportEntries(ctx, []ImportEntry{{ProjectName: "Client", StartTime: start, EndTime: start.Add(-time.Hour)}}, false)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// Dimensions a period report can be grouped by
+const (
+	ReportGroupDay      = "day"
+	ReportGroupProject  = "project"
+	ReportGroupCategory = "category"
+)
+
+// ReportGroups lists all valid report dimensions
+var ReportGroups = []string{ReportGroupDay, ReportGroupProject, ReportGroupCategory}
+
+// ReportRow holds the aggregated time of one group of a period report. Values holds the group's value for each
+// dimension of the report, an entry without category has an empty category value.
+type ReportRow struct {
+	Values    []string
+	Entries   int
+	WorkTime  time.Duration
+	PauseTime time.Duration
+	// Share is the fraction of the total work time of the report
+	Share float64
+	// Subtotal marks rows which sum up all groups sharing the value of the first dimension
+	Subtotal bool
+}
+
+// PeriodReport holds the tracked time within a period, grouped by one or more dimensions, and the time credited by
+// absences within the period
+type PeriodReport struct {
+	Start     time.Time
+	End       time.Time
+	GroupBy   []string
+	Rows      []ReportRow
+	Entries   int
+	WorkTime  time.Duration
+	PauseTime time.Duration
+	Absences  []AbsenceCredit
+	Credited  time.Duration
+}
+
+// Total returns the work time including the time credited by absences
+func (r *PeriodReport) Total() time.Duration {
+	return r.WorkTime + r.Credited
+}
+
+// CreditedShare returns the fraction of the total which is credited by absences
+func (r *PeriodReport) CreditedShare() float64 {
+	if r.Total() == 0 {
+		return 0
+	}
+
+	return float64(r.Credited) / float64(r.Total())
+}
+
+// GetPeriodReport aggregates the effective work and pause time of all entries which started within the given period
+// (end exclusive) by the given dimensions. Entries are accounted to the day they started, active entries count until
+// now. With more than one dimension a subtotal row follows each value of the first dimension. Absences within the
+// period credit their part of the expected work time of the schedule, the shares of the rows are based on the total
+// including the credited time.
+func (s *timeTracking) GetPeriodReport(ctx context.Context, schedule WorkSchedule, start time.Time, end time.Time, groupBy []string) (*PeriodReport, error) {
+	if err := validateReportGroups(groupBy); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.GetAllWithPausesFiltered(ctx, -1, "asc", repository.TimeEntryFilter{Since: &start, Until: &end})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	credits, err := s.getAbsenceCredits(ctx, schedule, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PeriodReport{Start: start, End: end, GroupBy: groupBy, Absences: credits}
+	for _, credit := range credits {
+		report.Credited += credit.Credited
+	}
+	groups := make(map[string]*ReportRow)
+
+	now := time.Now()
+	for _, entry := range entries {
+		workTime, pauseTime := time.Duration(0), entry.PauseTime
+		if entry.Duration != nil {
+			workTime = *entry.Duration
+		} else {
+			pauses, err := s.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pauses: %w", err)
+			}
+			workTime = calculateActiveWorkDuration(entry.StartTime, now, pauses)
+			pauseTime = now.Sub(entry.StartTime) - workTime
+		}
+
+		values := make([]string, len(groupBy))
+		for i, group := range groupBy {
+			values[i] = reportGroupValue(entry, group)
+		}
+
+		key := strings.Join(values, "\x00")
+		row, ok := groups[key]
+		if !ok {
+			row = &ReportRow{Values: values}
+			groups[key] = row
+		}
+
+		row.Entries++
+		row.WorkTime += workTime
+		row.PauseTime += pauseTime
+
+		report.Entries++
+		report.WorkTime += workTime
+		report.PauseTime += pauseTime
+	}
+
+	rows := make([]ReportRow, 0, len(groups))
+	for _, row := range groups {
+		rows = append(rows, *row)
+	}
+
+	slices.SortFunc(rows, func(a, b ReportRow) int {
+		return slices.Compare(a.Values, b.Values)
+	})
+
+	for _, row := range rows {
+		if len(groupBy) > 1 && len(report.Rows) > 0 {
+			last := report.Rows[len(report.Rows)-1]
+			if last.Values[0] != row.Values[0] {
+				report.Rows = append(report.Rows, subtotalRow(report.Rows, last.Values[0]))
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	if len(groupBy) > 1 && len(report.Rows) > 0 {
+		report.Rows = append(report.Rows, subtotalRow(report.Rows, report.Rows[len(report.Rows)-1].Values[0]))
+	}
+
+	for i := range report.Rows {
+		if report.Total() > 0 {
+			report.Rows[i].Share = float64(report.Rows[i].WorkTime) / float64(report.Total())
+		}
+	}
+
+	return report, nil
+}
+
+// subtotalRow sums up the rows which have the given value in their first dimension
+func subtotalRow(rows []ReportRow, value string) ReportRow {
+	subtotal := ReportRow{Values: make([]string, len(rows[0].Values)), Subtotal: true}
+	subtotal.Values[0] = value
+
+	for _, row := range rows {
+		if row.Subtotal || row.Values[0] != value {
+			continue
+		}
+		subtotal.Entries += row.Entries
+		subtotal.WorkTime += row.WorkTime
+		subtotal.PauseTime += row.PauseTime
+	}
+
+	return subtotal
+}
+
+// reportGroupValue returns the value of the given dimension for a time entry
+func reportGroupValue(entry repository.TimeEntryWithPauses, group string) string {
+	switch group {
+	case ReportGroupDay:
+		return startOfDay(entry.StartTime).Format(time.DateOnly)
+	case ReportGroupProject:
+		if entry.Project != nil {
+			return entry.Project.Name
+		}
+		return fmt.Sprintf("%d", entry.ProjectID)
+	default:
+		if entry.Category != nil {
+			return *entry.Category
+		}
+		return ""
+	}
+}
+
+// validateReportGroups validates the dimensions of a period report
+func validateReportGroups(groupBy []string) error {
+	if len(groupBy) == 0 {
+		return fmt.Errorf("at least one group is required")
+	}
+
+	for i, group := range groupBy {
+		if !slices.Contains(ReportGroups, group) {
+			return fmt.Errorf("invalid group %q, must be one of: %s", group, strings.Join(ReportGroups, ", "))
+		}
+		if slices.Contains(groupBy[:i], group) {
+			return fmt.Errorf("group %q is given more than once", group)
+		}
+	}
+
+	return nil
+}
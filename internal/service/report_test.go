@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_GetPeriodReport(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 7)
+
+	client := &model.Project{ID: 1, Name: "Client"}
+	internal := &model.Project{ID: 2, Name: "Internal"}
+	meeting := "meeting"
+
+	newEntry := func(project *model.Project, category *string, start time.Time, duration time.Duration, pauseTime time.Duration) repository.TimeEntryWithPauses {
+		end := start.Add(duration + pauseTime)
+		return repository.TimeEntryWithPauses{
+			TimeEntry: model.TimeEntry{ProjectID: project.ID, Project: project, Category: category, StartTime: start, EndTime: &end, Duration: &duration},
+			PauseTime: pauseTime,
+		}
+	}
+
+	entries := []repository.TimeEntryWithPauses{
+		newEntry(client, nil, start.Add(9*time.Hour), 3*time.Hour, 30*time.Minute),
+		newEntry(internal, nil, start.Add(14*time.Hour), time.Hour, 0),
+		newEntry(client, &meeting, start.AddDate(0, 0, 1).Add(9*time.Hour), time.Hour, 0),
+		newEntry(client, nil, start.AddDate(0, 0, 1).Add(11*time.Hour), 3*time.Hour, 0),
+	}
+
+	mockTimeEntryRepo.On("GetAllWithPausesFiltered", ctx, -1, "asc", repository.TimeEntryFilter{Since: &start, Until: &end}).Return(entries, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, mock.Anything, mock.Anything).Return([]model.Absence{}, nil)
+
+	report, err := service.GetPeriodReport(ctx, weekdaySchedule(8*time.Hour), start, end, []string{ReportGroupProject, ReportGroupCategory})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Entries)
+	assert.Equal(t, 8*time.Hour, report.WorkTime)
+	assert.Equal(t, 30*time.Minute, report.PauseTime)
+
+	require.Len(t, report.Rows, 5)
+	assert.Equal(t, []string{"Client", ""}, report.Rows[0].Values)
+	assert.Equal(t, 6*time.Hour, report.Rows[0].WorkTime)
+	assert.Equal(t, 30*time.Minute, report.Rows[0].PauseTime)
+	assert.Equal(t, 0.75, report.Rows[0].Share)
+	assert.Equal(t, []string{"Client", "meeting"}, report.Rows[1].Values)
+
+	assert.True(t, report.Rows[2].Subtotal)
+	assert.Equal(t, []string{"Client", ""}, report.Rows[2].Values)
+	assert.Equal(t, 3, report.Rows[2].Entries)
+	assert.Equal(t, 7*time.Hour, report.Rows[2].WorkTime)
+	assert.Equal(t, 0.875, report.Rows[2].Share)
+
+	assert.Equal(t, []string{"Internal", ""}, report.Rows[3].Values)
+	assert.True(t, report.Rows[4].Subtotal)
+	assert.Equal(t, time.Hour, report.Rows[4].WorkTime)
+}
+
+func TestTimeTracking_GetPeriodReport_ByDay(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 7)
+	first := 2 * time.Hour
+	firstEnd := start.Add(22 * time.Hour)
+	second := time.Hour
+	secondEnd := start.AddDate(0, 0, 1).Add(10 * time.Hour)
+
+	mockTimeEntryRepo.On("GetAllWithPausesFiltered", ctx, -1, "asc", mock.Anything).Return([]repository.TimeEntryWithPauses{
+		// Entries are accounted to the day they started, even when they end on the next day
+		{TimeEntry: model.TimeEntry{ProjectID: 1, StartTime: start.Add(22 * time.Hour), EndTime: &firstEnd, Duration: &first}},
+		{TimeEntry: model.TimeEntry{ProjectID: 1, StartTime: start.AddDate(0, 0, 1).Add(9 * time.Hour), EndTime: &secondEnd, Duration: &second}},
+	}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, mock.Anything, mock.Anything).Return([]model.Absence{}, nil)
+
+	report, err := service.GetPeriodReport(ctx, weekdaySchedule(8*time.Hour), start, end, []string{ReportGroupDay})
+
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, []string{"2025-10-20"}, report.Rows[0].Values)
+	assert.Equal(t, first, report.Rows[0].WorkTime)
+	assert.False(t, report.Rows[0].Subtotal)
+	assert.Equal(t, []string{"2025-10-21"}, report.Rows[1].Values)
+}
+
+func TestTimeTracking_GetPeriodReport_Absences(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	// Monday, 2025-10-20
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 7)
+	lastDay := start.AddDate(0, 0, 6)
+	work := 4 * time.Hour
+	workEnd := start.Add(13 * time.Hour)
+
+	mockTimeEntryRepo.On("GetAllWithPausesFiltered", ctx, -1, "asc", mock.Anything).Return([]repository.TimeEntryWithPauses{
+		{TimeEntry: model.TimeEntry{ProjectID: 1, StartTime: start.Add(9 * time.Hour), EndTime: &workEnd, Duration: &work}},
+	}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, &start, &lastDay).Return([]model.Absence{
+		{ID: 1, Date: start, Type: model.AbsenceTypeVacation, HalfDay: true},
+		{ID: 2, Date: start.AddDate(0, 0, 1), Type: model.AbsenceTypeSick},
+		// Weekends have no expected work time to credit
+		{ID: 3, Date: start.AddDate(0, 0, 5), Type: model.AbsenceTypeHoliday},
+	}, nil)
+
+	report, err := service.GetPeriodReport(ctx, weekdaySchedule(8*time.Hour), start, end, []string{ReportGroupProject})
+
+	require.NoError(t, err)
+	require.Len(t, report.Absences, 3)
+	assert.Equal(t, 4*time.Hour, report.Absences[0].Credited)
+	assert.Equal(t, 8*time.Hour, report.Absences[1].Credited)
+	assert.Equal(t, time.Duration(0), report.Absences[2].Credited)
+	assert.Equal(t, 12*time.Hour, report.Credited)
+	assert.Equal(t, 16*time.Hour, report.Total())
+
+	// Shares are based on the total including the credited time
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, 0.25, report.Rows[0].Share)
+	assert.Equal(t, 0.75, report.CreditedShare())
+}
+
+func TestTimeTracking_GetPeriodReport_InvalidGroups(t *testing.T) {
+	service := &timeTracking{}
+	start := time.Now()
+
+	for _, groupBy := range [][]string{nil, {"week"}, {ReportGroupDay, ReportGroupDay}} {
+		_, err := service.GetPeriodReport(context.Background(), weekdaySchedule(8*time.Hour), start, start, groupBy)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+// RoundingRules holds the configured rounding and the rounding overrides of all projects
+type RoundingRules struct {
+	defaults model.Rounding
+	projects map[int]model.Rounding
+}
+
+// NewRoundingRules creates rounding rules which apply the given rounding to all projects
+func NewRoundingRules(defaults model.Rounding) *RoundingRules {
+	return &RoundingRules{defaults: defaults, projects: make(map[int]model.Rounding)}
+}
+
+// For returns the rounding which applies to the given project
+func (r *RoundingRules) For(projectID int) model.Rounding {
+	if rounding, ok := r.projects[projectID]; ok {
+		return rounding
+	}
+
+	return r.defaults
+}
+
+// Enabled reports if the rounding of any project changes durations
+func (r *RoundingRules) Enabled() bool {
+	if r.defaults.Enabled() {
+		return true
+	}
+
+	for _, rounding := range r.projects {
+		if rounding.Enabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RoundEntries returns the rounded work time of the given completed entries by their ID. With the day or report
+// scope, the work time of a project is rounded per day respectively over all given entries, and the difference to the
+// exact work time is accounted to the last entries of the group without any of them becoming negative, so that the
+// rounded entries always sum up to the rounded total. Active entries are not included.
+func (r *RoundingRules) RoundEntries(entries []repository.TimeEntryWithPauses) map[int]time.Duration {
+	rounded := make(map[int]time.Duration)
+
+	type group struct {
+		rounding model.Rounding
+		entries  []model.TimeEntry
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for _, entry := range entries {
+		if entry.EndTime == nil || entry.Duration == nil {
+			continue
+		}
+
+		rounding := r.For(entry.ProjectID)
+		if rounding.Scope == model.RoundingScopeEntry || rounding.Scope == "" {
+			rounded[entry.ID] = rounding.Apply(*entry.Duration)
+			continue
+		}
+
+		key := fmt.Sprintf("%d", entry.ProjectID)
+		if rounding.Scope == model.RoundingScopeDay {
+			key += "/" + startOfDay(entry.StartTime).Format(time.DateOnly)
+		}
+
+		if _, ok := groups[key]; !ok {
+			groups[key] = &group{rounding: rounding}
+			keys = append(keys, key)
+		}
+		groups[key].entries = append(groups[key].entries, entry.TimeEntry)
+	}
+
+	for _, key := range keys {
+		g := groups[key]
+		sort.SliceStable(g.entries, func(i, j int) bool {
+			return g.entries[i].StartTime.Before(g.entries[j].StartTime)
+		})
+
+		var total time.Duration
+		for _, entry := range g.entries {
+			total += *entry.Duration
+			rounded[entry.ID] = *entry.Duration
+		}
+
+		// Rounding up is accounted to the last entry, rounding down is taken from the last entries without letting any
+		// of them become negative
+		diff := g.rounding.Apply(total) - total
+		for i := len(g.entries) - 1; i >= 0 && diff != 0; i-- {
+			id := g.entries[i].ID
+			change := max(diff, -rounded[id])
+			rounded[id] += change
+			diff -= change
+		}
+	}
+
+	return rounded
+}
+
+// Total returns the sum of the rounded work time of the given entries, active entries are not included
+func (r *RoundingRules) Total(entries []repository.TimeEntryWithPauses) time.Duration {
+	var total time.Duration
+	for _, duration := range r.RoundEntries(entries) {
+		total += duration
+	}
+
+	return total
+}
+
+// GetRoundingRules returns the rounding rules of all projects, projects without an override use the given rounding
+func (s *timeTracking) GetRoundingRules(ctx context.Context, defaults model.Rounding) (*RoundingRules, error) {
+	projects, err := s.projectRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	rules := NewRoundingRules(defaults)
+	for _, project := range projects {
+		if override := project.RoundingOverride(); override != nil {
+			rules.projects[project.ID] = *override
+		}
+	}
+
+	return rules, nil
+}
+
+// SetProjectRounding sets the rounding rule which overrides the configured rounding for a project
+func (s *timeTracking) SetProjectRounding(ctx context.Context, projectIDOrName string, rounding model.Rounding) (*model.Project, error) {
+	if err := validateRounding(rounding); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.UpdateRounding(ctx, project.ID, &rounding); err != nil {
+		return nil, fmt.Errorf("failed to update rounding: %w", err)
+	}
+
+	project.RoundingMode = &rounding.Mode
+	project.RoundingIncrement = &rounding.Increment
+	project.RoundingScope = &rounding.Scope
+	return project, nil
+}
+
+// RemoveProjectRounding removes the rounding override of a project
+func (s *timeTracking) RemoveProjectRounding(ctx context.Context, projectIDOrName string) (*model.Project, error) {
+	project, err := s.projectRepo.GetByIDOrName(ctx, projectIDOrName)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if err := s.projectRepo.UpdateRounding(ctx, project.ID, nil); err != nil {
+		return nil, fmt.Errorf("failed to remove rounding: %w", err)
+	}
+
+	project.RoundingMode = nil
+	project.RoundingIncrement = nil
+	project.RoundingScope = nil
+	return project, nil
+}
+
+// validateRounding validates the mode, increment and scope of a rounding rule
+func validateRounding(rounding model.Rounding) error {
+	if !slices.Contains(model.RoundingModes, rounding.Mode) {
+		return fmt.Errorf("invalid rounding mode '%s', must be one of %v", rounding.Mode, model.RoundingModes)
+	}
+
+	if rounding.Increment < time.Minute {
+		return fmt.Errorf("rounding increment must be at least one minute")
+	}
+
+	if !slices.Contains(model.RoundingScopes, rounding.Scope) {
+		return fmt.Errorf("invalid rounding scope '%s', must be one of %v", rounding.Scope, model.RoundingScopes)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRounding_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding model.Rounding
+		duration time.Duration
+		expected time.Duration
+	}{
+		{"none", model.Rounding{Mode: model.RoundingModeNone, Increment: 15 * time.Minute}, 7 * time.Minute, 7 * time.Minute},
+		{"up", model.Rounding{Mode: model.RoundingModeUp, Increment: 15 * time.Minute}, 61 * time.Minute, 75 * time.Minute},
+		{"up exact", model.Rounding{Mode: model.RoundingModeUp, Increment: 15 * time.Minute}, time.Hour, time.Hour},
+		{"down", model.Rounding{Mode: model.RoundingModeDown, Increment: 6 * time.Minute}, 17 * time.Minute, 12 * time.Minute},
+		{"nearest down", model.Rounding{Mode: model.RoundingModeNearest, Increment: 6 * time.Minute}, 14 * time.Minute, 12 * time.Minute},
+		{"nearest up", model.Rounding{Mode: model.RoundingModeNearest, Increment: 6 * time.Minute}, 15 * time.Minute, 18 * time.Minute},
+		{"without increment", model.Rounding{Mode: model.RoundingModeUp}, 7 * time.Minute, 7 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rounding.Apply(tt.duration))
+		})
+	}
+}
+
+func TestRoundingRules_RoundEntries(t *testing.T) {
+	day := time.Date(2025, 10, 20, 9, 0, 0, 0, time.Local)
+
+	newEntry := func(id int, projectID int, start time.Time, duration time.Duration) repository.TimeEntryWithPauses {
+		end := start.Add(duration)
+		return repository.TimeEntryWithPauses{TimeEntry: model.TimeEntry{ID: id, ProjectID: projectID, StartTime: start, EndTime: &end, Duration: &duration}}
+	}
+
+	rules := NewRoundingRules(model.Rounding{Mode: model.RoundingModeUp, Increment: 15 * time.Minute, Scope: model.RoundingScopeEntry})
+	rules.projects[2] = model.Rounding{Mode: model.RoundingModeUp, Increment: 15 * time.Minute, Scope: model.RoundingScopeDay}
+
+	entries := []repository.TimeEntryWithPauses{
+		newEntry(1, 1, day, 50*time.Minute),
+		newEntry(2, 1, day.Add(2*time.Hour), 20*time.Minute),
+		// Project 2 is rounded per day, the difference is added to the last entry of the day
+		newEntry(4, 2, day.Add(3*time.Hour), 20*time.Minute),
+		newEntry(3, 2, day.Add(time.Hour), 20*time.Minute),
+		newEntry(5, 2, day.AddDate(0, 0, 1), 10*time.Minute),
+		// Active entries are not rounded
+		{TimeEntry: model.TimeEntry{ID: 6, ProjectID: 1, StartTime: day.AddDate(0, 0, 1)}},
+	}
+
+	rounded := rules.RoundEntries(entries)
+
+	assert.Equal(t, map[int]time.Duration{
+		1: time.Hour,
+		2: 30 * time.Minute,
+		3: 20 * time.Minute,
+		4: 25 * time.Minute,
+		5: 15 * time.Minute,
+	}, rounded)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, rules.Total(entries))
+	assert.True(t, rules.Enabled())
+}
+
+func TestRoundingRules_RoundEntries_ReportScope(t *testing.T) {
+	day := time.Date(2025, 10, 20, 9, 0, 0, 0, time.Local)
+	first := 7 * time.Minute
+	second := 8 * time.Minute
+	firstEnd := day.Add(first)
+	secondEnd := day.AddDate(0, 0, 1).Add(second)
+
+	rules := NewRoundingRules(model.Rounding{Mode: model.RoundingModeNearest, Increment: 6 * time.Minute, Scope: model.RoundingScopeReport})
+
+	total := rules.Total([]repository.TimeEntryWithPauses{
+		{TimeEntry: model.TimeEntry{ID: 1, ProjectID: 1, StartTime: day, EndTime: &firstEnd, Duration: &first}},
+		{TimeEntry: model.TimeEntry{ID: 2, ProjectID: 1, StartTime: day.AddDate(0, 0, 1), EndTime: &secondEnd, Duration: &second}},
+	})
+
+	// 15 minutes over all entries round to 18 minutes, while each entry alone would round to 6 minutes
+	assert.Equal(t, 18*time.Minute, total)
+}
+
+func TestRoundingRules_RoundEntries_DownNotNegative(t *testing.T) {
+	day := time.Date(2025, 10, 20, 9, 0, 0, 0, time.Local)
+	duration := 5 * time.Minute
+	firstEnd := day.Add(duration)
+	secondEnd := day.Add(time.Hour + duration)
+
+	rules := NewRoundingRules(model.Rounding{Mode: model.RoundingModeDown, Increment: 15 * time.Minute, Scope: model.RoundingScopeDay})
+
+	rounded := rules.RoundEntries([]repository.TimeEntryWithPauses{
+		{TimeEntry: model.TimeEntry{ID: 1, ProjectID: 1, StartTime: day, EndTime: &firstEnd, Duration: &duration}},
+		{TimeEntry: model.TimeEntry{ID: 2, ProjectID: 1, StartTime: day.Add(time.Hour), EndTime: &secondEnd, Duration: &duration}},
+	})
+
+	// 10 minutes round down to zero, which is taken from the last entries instead of making the last one negative
+	assert.Equal(t, map[int]time.Duration{1: 0, 2: 0}, rounded)
+}
+
+func TestTimeTracking_GetRoundingRules(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+
+	service := &timeTracking{
+		projectRepo: mockProjectRepo,
+	}
+
+	mode := model.RoundingModeDown
+	increment := 6 * time.Minute
+	scope := model.RoundingScopeEntry
+	mockProjectRepo.On("GetAll", ctx).Return([]model.Project{
+		{ID: 1, Name: "Default"},
+		{ID: 2, Name: "Override", RoundingMode: &mode, RoundingIncrement: &increment, RoundingScope: &scope},
+	}, nil)
+
+	defaults := model.Rounding{Mode: model.RoundingModeUp, Increment: 15 * time.Minute, Scope: model.RoundingScopeEntry}
+	rules, err := service.GetRoundingRules(ctx, defaults)
+
+	require.NoError(t, err)
+	assert.Equal(t, defaults, rules.For(1))
+	assert.Equal(t, model.Rounding{Mode: mode, Increment: increment, Scope: scope}, rules.For(2))
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectRounding(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+
+	service := &timeTracking{
+		projectRepo: mockProjectRepo,
+	}
+
+	rounding := model.Rounding{Mode: model.RoundingModeUp, Increment: 6 * time.Minute, Scope: model.RoundingScopeDay}
+	mockProjectRepo.On("GetByIDOrName", ctx, "Client").Return(&model.Project{ID: 1, Name: "Client"}, nil)
+	mockProjectRepo.On("UpdateRounding", ctx, 1, &rounding).Return(nil)
+
+	project, err := service.SetProjectRounding(ctx, "Client", rounding)
+
+	require.NoError(t, err)
+	assert.Equal(t, &rounding, project.RoundingOverride())
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SetProjectRounding_Invalid(t *testing.T) {
+	service := &timeTracking{}
+
+	_, err := service.SetProjectRounding(context.Background(), "Client", model.Rounding{Mode: "sideways", Increment: 6 * time.Minute, Scope: model.RoundingScopeEntry})
+	assert.Error(t, err)
+
+	_, err = service.SetProjectRounding(context.Background(), "Client", model.Rounding{Mode: model.RoundingModeUp, Increment: 30 * time.Second, Scope: model.RoundingScopeEntry})
+	assert.Error(t, err)
+}
@@ -3,31 +3,85 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/nitschmann/hora/internal/model"
 	"github.com/nitschmann/hora/internal/repository"
 )
 
+// EntryAttributes defines the optional attributes of a new time entry. Entries are billable unless NonBillable is set.
+type EntryAttributes struct {
+	Category    *string
+	Notes       *string
+	Tags        []string
+	NonBillable bool
+}
+
+// TimeEntryChanges defines the changes to apply to an existing time entry. Nil fields are left untouched, an empty
+// Category removes the category of the entry and an empty (non-nil) Tags slice removes all tags.
+type TimeEntryChanges struct {
+	ProjectName *string
+	StartTime   *time.Time
+	EndTime     *time.Time
+	Category    *string
+	Tags        []string
+	Billable    *bool
+}
+
 // TimeTracking defines the interface for time tracking operations
 type TimeTracking interface {
-	StartTracking(ctx context.Context, projectName string, force bool, category *string) error
-	StopTracking(ctx context.Context) (*model.TimeEntry, error)
+	StartTracking(ctx context.Context, projectName string, force bool, attrs EntryAttributes, startTime time.Time) error
+	StopTracking(ctx context.Context, endTime time.Time, note string) (*model.TimeEntry, error)
+	SwitchTracking(ctx context.Context, projectName string, category *string, switchTime time.Time) (*model.TimeEntry, error)
+	AddEntry(ctx context.Context, projectName string, startTime time.Time, endTime time.Time, attrs EntryAttributes, pauses []model.Pause) (*model.TimeEntry, error)
+	EditEntry(ctx context.Context, id int, changes TimeEntryChanges) (*model.TimeEntry, error)
 	GetActiveEntry(ctx context.Context) (*model.TimeEntry, error)
+	GetEntryByID(ctx context.Context, id int) (*model.TimeEntry, error)
+	GetPausesForEntry(ctx context.Context, timeEntryID int) ([]model.Pause, error)
 	GetEntries(ctx context.Context, limit int) ([]model.TimeEntry, error)
 	GetEntriesForProject(ctx context.Context, projectIDOrName string, limit int, sortOrder string) ([]model.TimeEntry, error)
-	GetEntriesForProjectWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error)
+	GetEntriesForProjectWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]repository.TimeEntryWithPauses, error)
 	GetAllEntriesWithPauses(ctx context.Context, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error)
 	GetAllEntriesWithPausesByCategory(ctx context.Context, limit int, sortOrder string, since *time.Time, category *string) ([]repository.TimeEntryWithPauses, error)
-	GetTotalTimeForProject(ctx context.Context, projectIDOrName string, since *time.Time) (time.Duration, error)
+	GetAllEntriesWithPausesFiltered(ctx context.Context, limit int, sortOrder string, filter repository.TimeEntryFilter) ([]repository.TimeEntryWithPauses, error)
+	GetTotalTimeForProject(ctx context.Context, projectIDOrName string, since *time.Time, until *time.Time) (time.Duration, error)
 	ClearAllData(ctx context.Context) error
 	GetProjects(ctx context.Context) ([]model.Project, error)
 	GetOrCreateProject(ctx context.Context, name string) (*model.Project, error)
 	GetProjectByIDOrName(ctx context.Context, idOrName string) (*model.Project, error)
 	RemoveProject(ctx context.Context, idOrName string) error
-	PauseTracking(ctx context.Context) error
-	ContinueTracking(ctx context.Context) error
+	RemoveEntry(ctx context.Context, id int) (*model.TimeEntry, error)
+	RemovePause(ctx context.Context, id int) (*model.TimeEntry, error)
+	PauseTracking(ctx context.Context, pauseStart time.Time) error
+	ContinueTracking(ctx context.Context, pauseEnd time.Time) error
+	AppendNote(ctx context.Context, note string) (*model.TimeEntry, error)
 	GetCategories(ctx context.Context) ([]string, error)
+	GetTags(ctx context.Context) ([]repository.TagWithUsage, error)
+	SetProjectRate(ctx context.Context, projectIDOrName string, hourlyRate float64, currency string) (*model.Project, error)
+	RemoveProjectRate(ctx context.Context, projectIDOrName string) (*model.Project, error)
+	SetCategoryRate(ctx context.Context, projectIDOrName string, category string, hourlyRate float64) (*model.Project, error)
+	RemoveCategoryRate(ctx context.Context, projectIDOrName string, category string) (*model.Project, error)
+	GetBillingRates(ctx context.Context) (*BillingRates, error)
+	GetRevenueReport(ctx context.Context, since *time.Time, until *time.Time) ([]ProjectRevenue, error)
+	SetProjectBudget(ctx context.Context, projectIDOrName string, budget time.Duration, period string) (*model.Project, error)
+	RemoveProjectBudget(ctx context.Context, projectIDOrName string) (*model.Project, error)
+	GetBudgetStatus(ctx context.Context, projectIDOrName string, at time.Time) (*BudgetStatus, error)
+	GetRoundingRules(ctx context.Context, defaults model.Rounding) (*RoundingRules, error)
+	SetProjectRounding(ctx context.Context, projectIDOrName string, rounding model.Rounding) (*model.Project, error)
+	RemoveProjectRounding(ctx context.Context, projectIDOrName string) (*model.Project, error)
+	GetBalance(ctx context.Context, schedule WorkSchedule, weekStart time.Weekday, since *time.Time, until time.Time) (*Balance, error)
+	AddAbsence(ctx context.Context, date time.Time, absenceType string, halfDay bool, note *string) (*model.Absence, error)
+	GetAbsences(ctx context.Context, since *time.Time, until *time.Time) ([]model.Absence, error)
+	RemoveAbsence(ctx context.Context, id int) (*model.Absence, error)
+	ImportAbsences(ctx context.Context, absences []model.Absence) (int, error)
+	CheckCompliance(ctx context.Context, rules ComplianceRules, since *time.Time, until time.Time) ([]ComplianceViolation, error)
+	GetPeriodReport(ctx context.Context, schedule WorkSchedule, start time.Time, end time.Time, groupBy []string) (*PeriodReport, error)
+	GetTimesheet(ctx context.Context, schedule WorkSchedule, start time.Time, byCategory bool) (*Timesheet, error)
+	CreateBackup(ctx context.Context) (*Backup, error)
+	RestoreBackup(ctx context.Context, backup *Backup, mode string) (*RestoreResult, error)
+	ImportEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error)
 	FormatDuration(duration time.Duration) string
 }
 
@@ -36,29 +90,78 @@ type timeTracking struct {
 	projectRepo   repository.Project
 	timeEntryRepo repository.TimeEntry
 	pauseRepo     repository.Pause
+	tagRepo       repository.Tag
+	absenceRepo   repository.Absence
+	transactor    repository.Transactor
 }
 
 // NewTimeTracking creates a new time tracking service
-func NewTimeTracking(projectRepo repository.Project, timeEntryRepo repository.TimeEntry, pauseRepo repository.Pause) TimeTracking {
+func NewTimeTracking(projectRepo repository.Project, timeEntryRepo repository.TimeEntry, pauseRepo repository.Pause, tagRepo repository.Tag, absenceRepo repository.Absence, transactor repository.Transactor) TimeTracking {
 	return &timeTracking{
 		projectRepo:   projectRepo,
 		timeEntryRepo: timeEntryRepo,
 		pauseRepo:     pauseRepo,
+		tagRepo:       tagRepo,
+		absenceRepo:   absenceRepo,
+		transactor:    transactor,
 	}
 }
 
-// StartTracking starts a new time tracking session for the given project
-func (s *timeTracking) StartTracking(ctx context.Context, projectName string, force bool, category *string) error {
+// withinTransaction runs fn with a time tracking service whose repositories are bound to a single database
+// transaction, so all changes of fn are rolled back if it returns an error
+func (s *timeTracking) withinTransaction(ctx context.Context, fn func(tx *timeTracking) error) error {
+	return s.transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		return fn(&timeTracking{
+			projectRepo:   repos.Project,
+			timeEntryRepo: repos.TimeEntry,
+			pauseRepo:     repos.Pause,
+			tagRepo:       repos.Tag,
+			absenceRepo:   repos.Absence,
+		})
+	})
+}
+
+// StartTracking starts a new time tracking session for the given project at the given start time. When forcing, the
+// active session is stopped at the start time within the same database transaction.
+func (s *timeTracking) StartTracking(ctx context.Context, projectName string, force bool, attrs EntryAttributes, startTime time.Time) error {
+	return s.withinTransaction(ctx, func(tx *timeTracking) error {
+		return tx.startTracking(ctx, projectName, force, attrs, startTime)
+	})
+}
+
+// startTracking starts a new time tracking session with the repositories of the service, which have to be bound to a
+// transaction by the caller
+func (s *timeTracking) startTracking(ctx context.Context, projectName string, force bool, attrs EntryAttributes, startTime time.Time) error {
+	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
+	hasActive := err == nil && activeEntry != nil
+
 	// Check for active entry if not forcing
-	if !force {
-		activeEntry, err := s.timeEntryRepo.GetActive(ctx)
-		if err == nil && activeEntry != nil {
-			return fmt.Errorf("a time tracking session is already active for project '%s'", activeEntry.Project.Name)
+	if hasActive && !force {
+		return fmt.Errorf("a time tracking session is already active for project '%s'", activeEntry.Project.Name)
+	}
+
+	// Make sure the new entry does not overlap with already tracked time
+	checkUntil := time.Now()
+	if startTime.After(checkUntil) {
+		checkUntil = startTime
+	}
+
+	overlapping, err := s.timeEntryRepo.GetOverlapping(ctx, startTime, checkUntil)
+	if err != nil {
+		return fmt.Errorf("failed to check for overlapping time entries: %w", err)
+	}
+	for _, other := range overlapping {
+		// The active entry ends where the new one starts when forcing
+		if hasActive && other.ID == activeEntry.ID {
+			continue
 		}
-	} else {
-		// Stop all active entries when forcing
-		if err := s.timeEntryRepo.StopAllActive(ctx); err != nil {
-			return fmt.Errorf("failed to stop active entries: %w", err)
+		return fmt.Errorf("start time overlaps with existing entry %d for project '%s'", other.ID, other.Project.Name)
+	}
+
+	// Stop the active entry at the same time the new one starts when forcing
+	if hasActive {
+		if _, err := s.stopTracking(ctx, startTime); err != nil {
+			return fmt.Errorf("failed to stop active entry: %w", err)
 		}
 	}
 
@@ -69,52 +172,84 @@ func (s *timeTracking) StartTracking(ctx context.Context, projectName string, fo
 	}
 
 	// Create new time entry
-	_, err = s.timeEntryRepo.Create(ctx, proj.ID, time.Now(), category)
+	entry, err := s.timeEntryRepo.Create(ctx, proj.ID, startTime, attrs.Category, attrs.Notes, !attrs.NonBillable)
 	if err != nil {
 		return fmt.Errorf("failed to create time entry: %w", err)
 	}
 
+	if len(attrs.Tags) > 0 {
+		if err := s.tagRepo.SetForTimeEntry(ctx, entry.ID, attrs.Tags); err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// StopTracking stops the current active time tracking session
-func (s *timeTracking) StopTracking(ctx context.Context) (*model.TimeEntry, error) {
+// StopTracking stops the current active time tracking session at the given end time. A non-empty note is appended to
+// the notes of the session within the same database transaction, so it is only added if the session is stopped.
+func (s *timeTracking) StopTracking(ctx context.Context, endTime time.Time, note string) (*model.TimeEntry, error) {
+	var stoppedEntry *model.TimeEntry
+
+	err := s.withinTransaction(ctx, func(tx *timeTracking) error {
+		if strings.TrimSpace(note) != "" {
+			if _, err := tx.AppendNote(ctx, note); err != nil {
+				return fmt.Errorf("failed to add note: %w", err)
+			}
+		}
+
+		var err error
+		stoppedEntry, err = tx.stopTracking(ctx, endTime)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stoppedEntry, nil
+}
+
+// stopTracking stops the current active time tracking session with the repositories of the service, which have to be
+// bound to a transaction by the caller
+func (s *timeTracking) stopTracking(ctx context.Context, endTime time.Time) (*model.TimeEntry, error) {
 	// Get active entry
 	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("no active time tracking session found: %w", err)
 	}
 
+	if endTime.Before(activeEntry.StartTime) {
+		return nil, fmt.Errorf("end time must not be before the start of the active session (%s)", activeEntry.StartTime.Format(time.DateTime))
+	}
+
 	// End any active pause first
 	activePause, err := s.pauseRepo.GetActivePause(ctx, activeEntry.ID)
 	if err == nil {
+		if endTime.Before(activePause.PauseStart) {
+			return nil, fmt.Errorf("end time must not be before the start of the active pause (%s)", activePause.PauseStart.Format(time.DateTime))
+		}
+
 		// There's an active pause, end it
-		now := time.Now()
-		pauseDuration := now.Sub(activePause.PauseStart)
-		if err := s.pauseRepo.EndPause(ctx, activePause.ID, now, pauseDuration); err != nil {
+		pauseDuration := endTime.Sub(activePause.PauseStart)
+		if err := s.pauseRepo.EndPause(ctx, activePause.ID, endTime, pauseDuration); err != nil {
 			return nil, fmt.Errorf("failed to end active pause: %w", err)
 		}
 	}
 
-	// Calculate total duration minus pause time
-	endTime := time.Now()
-	totalDuration := endTime.Sub(activeEntry.StartTime)
-
 	// Get all pauses for this time entry to calculate total pause time
 	pauses, err := s.pauseRepo.GetByTimeEntry(ctx, activeEntry.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pauses: %w", err)
 	}
 
-	var totalPauseTime time.Duration
 	for _, pause := range pauses {
-		if pause.Duration != nil {
-			totalPauseTime += *pause.Duration
+		if pause.PauseEnd != nil && pause.PauseEnd.After(endTime) {
+			return nil, fmt.Errorf("end time must not be before the end of pause %d (%s)", pause.ID, pause.PauseEnd.Format(time.DateTime))
 		}
 	}
 
 	// Calculate actual work duration (total time minus pause time)
-	workDuration := totalDuration - totalPauseTime
+	workDuration := calculateWorkDuration(activeEntry.StartTime, endTime, pauses)
 
 	// Update the entry
 	if err := s.timeEntryRepo.UpdateEndTime(ctx, activeEntry.ID, endTime, workDuration); err != nil {
@@ -130,11 +265,216 @@ func (s *timeTracking) StopTracking(ctx context.Context) (*model.TimeEntry, erro
 	return updatedEntry, nil
 }
 
+// SwitchTracking stops the current active time tracking session and starts a new one for the given project at the
+// same instant within a single database transaction. It returns the stopped time entry.
+func (s *timeTracking) SwitchTracking(ctx context.Context, projectName string, category *string, switchTime time.Time) (*model.TimeEntry, error) {
+	var stoppedEntry *model.TimeEntry
+
+	err := s.withinTransaction(ctx, func(tx *timeTracking) error {
+		var err error
+		stoppedEntry, err = tx.stopTracking(ctx, switchTime)
+		if err != nil {
+			return err
+		}
+
+		return tx.startTracking(ctx, projectName, false, EntryAttributes{Category: category}, switchTime)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stoppedEntry, nil
+}
+
+// AddEntry creates a completed time entry with its pauses for the given project retroactively
+func (s *timeTracking) AddEntry(ctx context.Context, projectName string, startTime time.Time, endTime time.Time, attrs EntryAttributes, pauses []model.Pause) (*model.TimeEntry, error) {
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time must be after start time")
+	}
+
+	if endTime.After(time.Now()) {
+		return nil, fmt.Errorf("end time must not be in the future")
+	}
+
+	pauses, err := validatePauses(startTime, endTime, pauses)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *model.TimeEntry
+
+	// The entry is only created together with all its pauses and tags
+	err = s.withinTransaction(ctx, func(tx *timeTracking) error {
+		// Make sure the new entry does not overlap with already tracked time
+		overlapping, err := tx.timeEntryRepo.GetOverlapping(ctx, startTime, endTime)
+		if err != nil {
+			return fmt.Errorf("failed to check for overlapping time entries: %w", err)
+		}
+		if len(overlapping) > 0 {
+			return fmt.Errorf("time entry overlaps with existing entry %d for project '%s'", overlapping[0].ID, overlapping[0].Project.Name)
+		}
+
+		// Get or create project
+		proj, err := tx.projectRepo.GetOrCreate(ctx, projectName)
+		if err != nil {
+			return fmt.Errorf("failed to get or create project: %w", err)
+		}
+
+		workDuration := calculateWorkDuration(startTime, endTime, pauses)
+
+		entry, err = tx.timeEntryRepo.CreateCompleted(ctx, proj.ID, startTime, endTime, workDuration, attrs.Category, attrs.Notes, !attrs.NonBillable)
+		if err != nil {
+			return fmt.Errorf("failed to create time entry: %w", err)
+		}
+
+		for _, pause := range pauses {
+			_, err := tx.pauseRepo.CreateCompleted(ctx, entry.ID, pause.PauseStart, *pause.PauseEnd, *pause.Duration)
+			if err != nil {
+				return fmt.Errorf("failed to create pause: %w", err)
+			}
+		}
+
+		if len(attrs.Tags) > 0 {
+			if err := tx.tagRepo.SetForTimeEntry(ctx, entry.ID, attrs.Tags); err != nil {
+				return fmt.Errorf("failed to set tags: %w", err)
+			}
+			entry.Tags = attrs.Tags
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// EditEntry applies the given changes to an existing time entry and recomputes its work duration
+func (s *timeTracking) EditEntry(ctx context.Context, id int, changes TimeEntryChanges) (*model.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("time entry %d not found: %w", id, err)
+	}
+
+	if changes.StartTime != nil {
+		entry.StartTime = *changes.StartTime
+	}
+
+	if changes.EndTime != nil {
+		if entry.EndTime == nil {
+			return nil, fmt.Errorf("cannot set the end time of the active time entry, stop tracking instead")
+		}
+		entry.EndTime = changes.EndTime
+	}
+
+	if changes.Category != nil {
+		if *changes.Category == "" {
+			entry.Category = nil
+		} else {
+			entry.Category = changes.Category
+		}
+	}
+
+	if changes.Billable != nil {
+		entry.Billable = *changes.Billable
+	}
+
+	// Active entries are validated against the current time
+	endTime := time.Now()
+	if entry.EndTime != nil {
+		endTime = *entry.EndTime
+	}
+
+	if endTime.Before(entry.StartTime) {
+		return nil, fmt.Errorf("end time must not be before start time")
+	}
+
+	if entry.StartTime.After(time.Now()) {
+		return nil, fmt.Errorf("start time must not be in the future")
+	}
+
+	pauses, err := s.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pauses: %w", err)
+	}
+
+	for _, pause := range pauses {
+		if pause.PauseStart.Before(entry.StartTime) || (pause.PauseEnd != nil && pause.PauseEnd.After(endTime)) {
+			return nil, fmt.Errorf("pause %d would be outside of the time entry", pause.ID)
+		}
+	}
+
+	overlapping, err := s.timeEntryRepo.GetOverlapping(ctx, entry.StartTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for overlapping time entries: %w", err)
+	}
+	for _, other := range overlapping {
+		if other.ID != entry.ID {
+			return nil, fmt.Errorf("time entry overlaps with existing entry %d for project '%s'", other.ID, other.Project.Name)
+		}
+	}
+
+	if entry.EndTime != nil {
+		workDuration := calculateWorkDuration(entry.StartTime, *entry.EndTime, pauses)
+		entry.Duration = &workDuration
+	}
+
+	// The project is only created once the changes are valid, and together with the update of the entry
+	err = s.withinTransaction(ctx, func(tx *timeTracking) error {
+		if changes.ProjectName != nil {
+			proj, err := tx.projectRepo.GetOrCreate(ctx, *changes.ProjectName)
+			if err != nil {
+				return fmt.Errorf("failed to get or create project: %w", err)
+			}
+			entry.ProjectID = proj.ID
+			entry.Project = proj
+		}
+
+		if err := tx.timeEntryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to update time entry: %w", err)
+		}
+
+		if changes.Tags != nil {
+			if err := tx.tagRepo.SetForTimeEntry(ctx, entry.ID, changes.Tags); err != nil {
+				return fmt.Errorf("failed to set tags: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get updated entry
+	updatedEntry, err := s.timeEntryRepo.GetByID(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated time entry: %w", err)
+	}
+
+	return updatedEntry, nil
+}
+
 // GetActiveEntry returns the currently active time tracking entry, if any
 func (s *timeTracking) GetActiveEntry(ctx context.Context) (*model.TimeEntry, error) {
 	return s.timeEntryRepo.GetActive(ctx)
 }
 
+// GetEntryByID returns the time entry with the given ID
+func (s *timeTracking) GetEntryByID(ctx context.Context, id int) (*model.TimeEntry, error) {
+	return s.timeEntryRepo.GetByID(ctx, id)
+}
+
+// GetPausesForEntry returns all pauses of the time entry with the given ID
+func (s *timeTracking) GetPausesForEntry(ctx context.Context, timeEntryID int) ([]model.Pause, error) {
+	if _, err := s.timeEntryRepo.GetByID(ctx, timeEntryID); err != nil {
+		return nil, fmt.Errorf("time entry %d not found: %w", timeEntryID, err)
+	}
+
+	return s.pauseRepo.GetByTimeEntry(ctx, timeEntryID)
+}
+
 // GetEntries returns a list of time entries, limited by the given count
 func (s *timeTracking) GetEntries(ctx context.Context, limit int) ([]model.TimeEntry, error) {
 	return s.timeEntryRepo.GetAll(ctx, limit)
@@ -146,8 +486,8 @@ func (s *timeTracking) GetEntriesForProject(ctx context.Context, projectIDOrName
 }
 
 // GetEntriesForProjectWithPauses returns time entries with pause information for a project by ID (if numeric) or name
-func (s *timeTracking) GetEntriesForProjectWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error) {
-	return s.timeEntryRepo.GetByProjectIDOrNameWithPauses(ctx, projectIDOrName, limit, sortOrder, since)
+func (s *timeTracking) GetEntriesForProjectWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]repository.TimeEntryWithPauses, error) {
+	return s.timeEntryRepo.GetByProjectIDOrNameWithPauses(ctx, projectIDOrName, limit, sortOrder, since, until)
 }
 
 // GetAllEntriesWithPauses returns all time entries with pause information across all projects
@@ -160,9 +500,14 @@ func (s *timeTracking) GetAllEntriesWithPausesByCategory(ctx context.Context, li
 	return s.timeEntryRepo.GetAllWithPausesByCategory(ctx, limit, sortOrder, since, category)
 }
 
+// GetAllEntriesWithPausesFiltered returns all time entries with pause information across all projects matching the filter
+func (s *timeTracking) GetAllEntriesWithPausesFiltered(ctx context.Context, limit int, sortOrder string, filter repository.TimeEntryFilter) ([]repository.TimeEntryWithPauses, error) {
+	return s.timeEntryRepo.GetAllWithPausesFiltered(ctx, limit, sortOrder, filter)
+}
+
 // GetTotalTimeForProject returns the total tracked time for a project by ID (if numeric) or name
-func (s *timeTracking) GetTotalTimeForProject(ctx context.Context, projectIDOrName string, since *time.Time) (time.Duration, error) {
-	return s.timeEntryRepo.GetTotalTimeByProjectIDOrName(ctx, projectIDOrName, since)
+func (s *timeTracking) GetTotalTimeForProject(ctx context.Context, projectIDOrName string, since *time.Time, until *time.Time) (time.Duration, error) {
+	return s.timeEntryRepo.GetTotalTimeByProjectIDOrName(ctx, projectIDOrName, since, until)
 }
 
 // ClearAllData removes all time entries and projects from the database
@@ -172,11 +517,21 @@ func (s *timeTracking) ClearAllData(ctx context.Context) error {
 		return fmt.Errorf("failed to delete pauses: %w", err)
 	}
 
+	// Delete all tags
+	if err := s.tagRepo.DeleteAll(ctx); err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
 	// Delete all time entries
 	if err := s.timeEntryRepo.DeleteAll(ctx); err != nil {
 		return fmt.Errorf("failed to delete time entries: %w", err)
 	}
 
+	// Delete all absences
+	if err := s.absenceRepo.DeleteAll(ctx); err != nil {
+		return fmt.Errorf("failed to delete absences: %w", err)
+	}
+
 	return nil
 }
 
@@ -203,17 +558,109 @@ func (s *timeTracking) RemoveProject(ctx context.Context, idOrName string) error
 		return fmt.Errorf("project not found: %w", err)
 	}
 
-	// Delete time entries first
-	if err := s.timeEntryRepo.DeleteByProject(ctx, project.ID); err != nil {
-		return fmt.Errorf("failed to delete time entries: %w", err)
+	return s.withinTransaction(ctx, func(tx *timeTracking) error {
+		// Delete the pauses and tag assignments of the time entries first, then the time entries
+		if err := tx.pauseRepo.DeleteByProject(ctx, project.ID); err != nil {
+			return fmt.Errorf("failed to delete pauses: %w", err)
+		}
+
+		if err := tx.tagRepo.DeleteByProject(ctx, project.ID); err != nil {
+			return fmt.Errorf("failed to delete tags: %w", err)
+		}
+
+		if err := tx.timeEntryRepo.DeleteByProject(ctx, project.ID); err != nil {
+			return fmt.Errorf("failed to delete time entries: %w", err)
+		}
+
+		// Delete project by ID
+		return tx.projectRepo.DeleteByID(ctx, project.ID)
+	})
+}
+
+// RemoveEntry removes a time entry by ID including all its pauses and returns the removed entry
+func (s *timeTracking) RemoveEntry(ctx context.Context, id int) (*model.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("time entry %d not found: %w", id, err)
+	}
+
+	err = s.withinTransaction(ctx, func(tx *timeTracking) error {
+		// Delete pauses and tag assignments first
+		if err := tx.pauseRepo.DeleteByTimeEntry(ctx, entry.ID); err != nil {
+			return fmt.Errorf("failed to delete pauses: %w", err)
+		}
+
+		if err := tx.tagRepo.DeleteByTimeEntry(ctx, entry.ID); err != nil {
+			return fmt.Errorf("failed to delete tags: %w", err)
+		}
+
+		if err := tx.timeEntryRepo.DeleteByID(ctx, entry.ID); err != nil {
+			return fmt.Errorf("failed to delete time entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RemovePause removes a pause by ID and recomputes the work duration of its time entry.
+// It returns the updated time entry the pause belonged to.
+func (s *timeTracking) RemovePause(ctx context.Context, id int) (*model.TimeEntry, error) {
+	pause, err := s.pauseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("pause %d not found: %w", id, err)
+	}
+
+	var updatedEntry *model.TimeEntry
+
+	// The pause is only removed together with the update of the work duration
+	err = s.withinTransaction(ctx, func(tx *timeTracking) error {
+		if err := tx.pauseRepo.DeleteByID(ctx, pause.ID); err != nil {
+			return fmt.Errorf("failed to delete pause: %w", err)
+		}
+
+		entry, err := tx.timeEntryRepo.GetByID(ctx, pause.TimeEntryID)
+		if err != nil {
+			return fmt.Errorf("failed to get time entry: %w", err)
+		}
+
+		// Active entries get their duration calculated once they are stopped
+		if entry.EndTime == nil {
+			updatedEntry = entry
+			return nil
+		}
+
+		pauses, err := tx.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get pauses: %w", err)
+		}
+
+		workDuration := calculateWorkDuration(entry.StartTime, *entry.EndTime, pauses)
+		if err := tx.timeEntryRepo.UpdateEndTime(ctx, entry.ID, *entry.EndTime, workDuration); err != nil {
+			return fmt.Errorf("failed to update time entry: %w", err)
+		}
+
+		// Get updated entry
+		updatedEntry, err = tx.timeEntryRepo.GetByID(ctx, entry.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get updated time entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete project by ID
-	return s.projectRepo.DeleteByID(ctx, project.ID)
+	return updatedEntry, nil
 }
 
-// PauseTracking pauses the currently active time tracking session
-func (s *timeTracking) PauseTracking(ctx context.Context) error {
+// PauseTracking pauses the currently active time tracking session at the given pause start
+func (s *timeTracking) PauseTracking(ctx context.Context, pauseStart time.Time) error {
 	// Get the active time entry
 	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
 	if err != nil {
@@ -226,8 +673,23 @@ func (s *timeTracking) PauseTracking(ctx context.Context) error {
 		return fmt.Errorf("time tracking is already paused")
 	}
 
+	if pauseStart.Before(activeEntry.StartTime) {
+		return fmt.Errorf("pause start must not be before the start of the active session (%s)", activeEntry.StartTime.Format(time.DateTime))
+	}
+
+	pauses, err := s.pauseRepo.GetByTimeEntry(ctx, activeEntry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pauses: %w", err)
+	}
+
+	for _, pause := range pauses {
+		if pause.PauseEnd != nil && pause.PauseEnd.After(pauseStart) {
+			return fmt.Errorf("pause start must not be before the end of pause %d (%s)", pause.ID, pause.PauseEnd.Format(time.DateTime))
+		}
+	}
+
 	// Create a new pause
-	_, err = s.pauseRepo.Create(ctx, activeEntry.ID, time.Now())
+	_, err = s.pauseRepo.Create(ctx, activeEntry.ID, pauseStart)
 	if err != nil {
 		return fmt.Errorf("failed to create pause: %w", err)
 	}
@@ -235,8 +697,8 @@ func (s *timeTracking) PauseTracking(ctx context.Context) error {
 	return nil
 }
 
-// ContinueTracking continues the currently paused time tracking session
-func (s *timeTracking) ContinueTracking(ctx context.Context) error {
+// ContinueTracking continues the currently paused time tracking session at the given pause end
+func (s *timeTracking) ContinueTracking(ctx context.Context, pauseEnd time.Time) error {
 	// Get the active time entry
 	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
 	if err != nil {
@@ -249,10 +711,13 @@ func (s *timeTracking) ContinueTracking(ctx context.Context) error {
 		return fmt.Errorf("no active pause found: %w", err)
 	}
 
+	if pauseEnd.Before(activePause.PauseStart) {
+		return fmt.Errorf("pause end must not be before the start of the active pause (%s)", activePause.PauseStart.Format(time.DateTime))
+	}
+
 	// End the pause
-	now := time.Now()
-	duration := now.Sub(activePause.PauseStart)
-	err = s.pauseRepo.EndPause(ctx, activePause.ID, now, duration)
+	duration := pauseEnd.Sub(activePause.PauseStart)
+	err = s.pauseRepo.EndPause(ctx, activePause.ID, pauseEnd, duration)
 	if err != nil {
 		return fmt.Errorf("failed to end pause: %w", err)
 	}
@@ -260,11 +725,42 @@ func (s *timeTracking) ContinueTracking(ctx context.Context) error {
 	return nil
 }
 
+// AppendNote appends a note to the notes of the currently active time tracking session
+func (s *timeTracking) AppendNote(ctx context.Context, note string) (*model.TimeEntry, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, fmt.Errorf("note must not be empty")
+	}
+
+	activeEntry, err := s.timeEntryRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no active time tracking session found: %w", err)
+	}
+
+	notes := note
+	if activeEntry.Notes != nil && *activeEntry.Notes != "" {
+		notes = *activeEntry.Notes + "\n" + note
+	}
+
+	err = s.timeEntryRepo.UpdateNotes(ctx, activeEntry.ID, &notes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notes: %w", err)
+	}
+
+	activeEntry.Notes = &notes
+	return activeEntry, nil
+}
+
 // GetCategories returns all unique categories from time entries
 func (s *timeTracking) GetCategories(ctx context.Context) ([]string, error) {
 	return s.timeEntryRepo.GetCategories(ctx)
 }
 
+// GetTags returns all tags with the number of time entries they are assigned to
+func (s *timeTracking) GetTags(ctx context.Context) ([]repository.TagWithUsage, error) {
+	return s.tagRepo.GetAllWithUsage(ctx)
+}
+
 // FormatDuration formats a duration into HH:MM:SS format
 func (s *timeTracking) FormatDuration(duration time.Duration) string {
 	hours := int(duration.Hours())
@@ -272,3 +768,49 @@ func (s *timeTracking) FormatDuration(duration time.Duration) string {
 	seconds := int(duration.Seconds()) % 60
 	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 }
+
+// calculateWorkDuration calculates the actual work duration of a time entry (total time minus pause time)
+func calculateWorkDuration(startTime time.Time, endTime time.Time, pauses []model.Pause) time.Duration {
+	var totalPauseTime time.Duration
+	for _, pause := range pauses {
+		if pause.Duration != nil {
+			totalPauseTime += *pause.Duration
+		}
+	}
+
+	return endTime.Sub(startTime) - totalPauseTime
+}
+
+// validatePauses validates that the given pauses are completed, lie within the given time range and do not
+// overlap each other. It returns the pauses sorted by start time with their durations set.
+func validatePauses(startTime time.Time, endTime time.Time, pauses []model.Pause) ([]model.Pause, error) {
+	sorted := make([]model.Pause, len(pauses))
+	copy(sorted, pauses)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PauseStart.Before(sorted[j].PauseStart)
+	})
+
+	for i := range sorted {
+		pause := &sorted[i]
+		if pause.PauseEnd == nil {
+			return nil, fmt.Errorf("pause starting at %s has no end time", pause.PauseStart.Format(time.DateTime))
+		}
+
+		if !pause.PauseEnd.After(pause.PauseStart) {
+			return nil, fmt.Errorf("pause end must be after pause start (%s)", pause.PauseStart.Format(time.DateTime))
+		}
+
+		if pause.PauseStart.Before(startTime) || pause.PauseEnd.After(endTime) {
+			return nil, fmt.Errorf("pause %s - %s is outside of the time entry", pause.PauseStart.Format(time.DateTime), pause.PauseEnd.Format(time.DateTime))
+		}
+
+		if i > 0 && pause.PauseStart.Before(*sorted[i-1].PauseEnd) {
+			return nil, fmt.Errorf("pauses must not overlap (%s)", pause.PauseStart.Format(time.DateTime))
+		}
+
+		duration := pause.PauseEnd.Sub(pause.PauseStart)
+		pause.Duration = &duration
+	}
+
+	return sorted, nil
+}
@@ -21,6 +21,16 @@ func (m *MockProjectRepo) Create(ctx context.Context, name string) (*model.Proje
 	return args.Get(0).(*model.Project), args.Error(1)
 }
 
+func (m *MockProjectRepo) Insert(ctx context.Context, project model.Project) (int, error) {
+	args := m.Called(ctx, project)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProjectRepo) DeleteAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func (m *MockProjectRepo) GetByID(ctx context.Context, id int) (*model.Project, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*model.Project), args.Error(1)
@@ -56,12 +66,47 @@ func (m *MockProjectRepo) GetByIDOrName(ctx context.Context, idOrName string) (*
 	return args.Get(0).(*model.Project), args.Error(1)
 }
 
+func (m *MockProjectRepo) UpdateRate(ctx context.Context, id int, hourlyRate *float64, currency *string) error {
+	args := m.Called(ctx, id, hourlyRate, currency)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepo) SetCategoryRate(ctx context.Context, projectID int, category string, hourlyRate float64) error {
+	args := m.Called(ctx, projectID, category, hourlyRate)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepo) DeleteCategoryRate(ctx context.Context, projectID int, category string) error {
+	args := m.Called(ctx, projectID, category)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepo) GetCategoryRates(ctx context.Context) ([]model.CategoryRate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.CategoryRate), args.Error(1)
+}
+
+func (m *MockProjectRepo) UpdateBudget(ctx context.Context, id int, budget *time.Duration, period *string) error {
+	args := m.Called(ctx, id, budget, period)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepo) UpdateRounding(ctx context.Context, id int, rounding *model.Rounding) error {
+	args := m.Called(ctx, id, rounding)
+	return args.Error(0)
+}
+
 type MockTimeEntryRepo struct {
 	mock.Mock
 }
 
-func (m *MockTimeEntryRepo) Create(ctx context.Context, projectID int, startTime time.Time, category *string) (*model.TimeEntry, error) {
-	args := m.Called(ctx, projectID, startTime, category)
+func (m *MockTimeEntryRepo) Create(ctx context.Context, projectID int, startTime time.Time, category *string, notes *string, billable bool) (*model.TimeEntry, error) {
+	args := m.Called(ctx, projectID, startTime, category, notes, billable)
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepo) CreateCompleted(ctx context.Context, projectID int, startTime time.Time, endTime time.Time, duration time.Duration, category *string, notes *string, billable bool) (*model.TimeEntry, error) {
+	args := m.Called(ctx, projectID, startTime, endTime, duration, category, notes, billable)
 	return args.Get(0).(*model.TimeEntry), args.Error(1)
 }
 
@@ -70,11 +115,36 @@ func (m *MockTimeEntryRepo) GetByID(ctx context.Context, id int) (*model.TimeEnt
 	return args.Get(0).(*model.TimeEntry), args.Error(1)
 }
 
+func (m *MockTimeEntryRepo) Insert(ctx context.Context, entry model.TimeEntry) (int, error) {
+	args := m.Called(ctx, entry)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTimeEntryRepo) GetByProjectAndStartTime(ctx context.Context, projectID int, startTime time.Time) (*model.TimeEntry, error) {
+	args := m.Called(ctx, projectID, startTime)
+	return args.Get(0).(*model.TimeEntry), args.Error(1)
+}
+
 func (m *MockTimeEntryRepo) GetActive(ctx context.Context) (*model.TimeEntry, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(*model.TimeEntry), args.Error(1)
 }
 
+func (m *MockTimeEntryRepo) GetOverlapping(ctx context.Context, startTime time.Time, endTime time.Time) ([]model.TimeEntry, error) {
+	args := m.Called(ctx, startTime, endTime)
+	return args.Get(0).([]model.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepo) Update(ctx context.Context, entry *model.TimeEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepo) UpdateNotes(ctx context.Context, id int, notes *string) error {
+	args := m.Called(ctx, id, notes)
+	return args.Error(0)
+}
+
 func (m *MockTimeEntryRepo) UpdateEndTime(ctx context.Context, id int, endTime time.Time, duration time.Duration) error {
 	args := m.Called(ctx, id, endTime, duration)
 	return args.Error(0)
@@ -95,13 +165,13 @@ func (m *MockTimeEntryRepo) GetByProjectName(ctx context.Context, projectName st
 	return args.Get(0).([]model.TimeEntry), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetByProjectWithPauses(ctx context.Context, projectID int, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error) {
-	args := m.Called(ctx, projectID, limit, sortOrder, since)
+func (m *MockTimeEntryRepo) GetByProjectWithPauses(ctx context.Context, projectID int, limit int, sortOrder string, since *time.Time, until *time.Time) ([]repository.TimeEntryWithPauses, error) {
+	args := m.Called(ctx, projectID, limit, sortOrder, since, until)
 	return args.Get(0).([]repository.TimeEntryWithPauses), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetByProjectNameWithPauses(ctx context.Context, projectName string, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error) {
-	args := m.Called(ctx, projectName, limit, sortOrder, since)
+func (m *MockTimeEntryRepo) GetByProjectNameWithPauses(ctx context.Context, projectName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]repository.TimeEntryWithPauses, error) {
+	args := m.Called(ctx, projectName, limit, sortOrder, since, until)
 	return args.Get(0).([]repository.TimeEntryWithPauses), args.Error(1)
 }
 
@@ -120,16 +190,26 @@ func (m *MockTimeEntryRepo) GetAllWithPausesByCategory(ctx context.Context, limi
 	return args.Get(0).([]repository.TimeEntryWithPauses), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetTotalTimeByProject(ctx context.Context, projectID int, since *time.Time) (time.Duration, error) {
-	args := m.Called(ctx, projectID, since)
+func (m *MockTimeEntryRepo) GetAllWithPausesFiltered(ctx context.Context, limit int, sortOrder string, filter repository.TimeEntryFilter) ([]repository.TimeEntryWithPauses, error) {
+	args := m.Called(ctx, limit, sortOrder, filter)
+	return args.Get(0).([]repository.TimeEntryWithPauses), args.Error(1)
+}
+
+func (m *MockTimeEntryRepo) GetTotalTimeByProject(ctx context.Context, projectID int, since *time.Time, until *time.Time) (time.Duration, error) {
+	args := m.Called(ctx, projectID, since, until)
 	return args.Get(0).(time.Duration), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetTotalTimeByProjectName(ctx context.Context, projectName string, since *time.Time) (time.Duration, error) {
-	args := m.Called(ctx, projectName, since)
+func (m *MockTimeEntryRepo) GetTotalTimeByProjectName(ctx context.Context, projectName string, since *time.Time, until *time.Time) (time.Duration, error) {
+	args := m.Called(ctx, projectName, since, until)
 	return args.Get(0).(time.Duration), args.Error(1)
 }
 
+func (m *MockTimeEntryRepo) DeleteByID(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockTimeEntryRepo) DeleteByProject(ctx context.Context, projectID int) error {
 	args := m.Called(ctx, projectID)
 	return args.Error(0)
@@ -145,13 +225,18 @@ func (m *MockTimeEntryRepo) GetByProjectIDOrName(ctx context.Context, projectIDO
 	return args.Get(0).([]model.TimeEntry), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time) ([]repository.TimeEntryWithPauses, error) {
-	args := m.Called(ctx, projectIDOrName, limit, sortOrder, since)
+func (m *MockTimeEntryRepo) GetByProjectIDOrNameWithPauses(ctx context.Context, projectIDOrName string, limit int, sortOrder string, since *time.Time, until *time.Time) ([]repository.TimeEntryWithPauses, error) {
+	args := m.Called(ctx, projectIDOrName, limit, sortOrder, since, until)
 	return args.Get(0).([]repository.TimeEntryWithPauses), args.Error(1)
 }
 
-func (m *MockTimeEntryRepo) GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time) (time.Duration, error) {
-	args := m.Called(ctx, projectIDOrName, since)
+func (m *MockTimeEntryRepo) GetTotalTimeByProjectIDOrName(ctx context.Context, projectIDOrName string, since *time.Time, until *time.Time) (time.Duration, error) {
+	args := m.Called(ctx, projectIDOrName, since, until)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockTimeEntryRepo) GetWorkTimeByProject(ctx context.Context, projectID int, since *time.Time) (time.Duration, error) {
+	args := m.Called(ctx, projectID, since)
 	return args.Get(0).(time.Duration), args.Error(1)
 }
 
@@ -169,11 +254,26 @@ func (m *MockPauseRepo) Create(ctx context.Context, timeEntryID int, pauseStart
 	return args.Get(0).(*model.Pause), args.Error(1)
 }
 
+func (m *MockPauseRepo) CreateCompleted(ctx context.Context, timeEntryID int, pauseStart time.Time, pauseEnd time.Time, duration time.Duration) (*model.Pause, error) {
+	args := m.Called(ctx, timeEntryID, pauseStart, pauseEnd, duration)
+	return args.Get(0).(*model.Pause), args.Error(1)
+}
+
 func (m *MockPauseRepo) GetByID(ctx context.Context, id int) (*model.Pause, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*model.Pause), args.Error(1)
 }
 
+func (m *MockPauseRepo) Insert(ctx context.Context, pause model.Pause) (int, error) {
+	args := m.Called(ctx, pause)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPauseRepo) GetAll(ctx context.Context) ([]model.Pause, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Pause), args.Error(1)
+}
+
 func (m *MockPauseRepo) GetActivePause(ctx context.Context, timeEntryID int) (*model.Pause, error) {
 	args := m.Called(ctx, timeEntryID)
 	return args.Get(0).(*model.Pause), args.Error(1)
@@ -189,36 +289,131 @@ func (m *MockPauseRepo) GetByTimeEntry(ctx context.Context, timeEntryID int) ([]
 	return args.Get(0).([]model.Pause), args.Error(1)
 }
 
+func (m *MockPauseRepo) DeleteByID(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockPauseRepo) DeleteByTimeEntry(ctx context.Context, timeEntryID int) error {
 	args := m.Called(ctx, timeEntryID)
 	return args.Error(0)
 }
 
+func (m *MockPauseRepo) DeleteByProject(ctx context.Context, projectID int) error {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
 func (m *MockPauseRepo) DeleteAll(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
 
+type MockTagRepo struct {
+	mock.Mock
+}
+
+func (m *MockTagRepo) GetByName(ctx context.Context, name string) (*model.Tag, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*model.Tag), args.Error(1)
+}
+
+func (m *MockTagRepo) GetOrCreate(ctx context.Context, name string) (*model.Tag, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*model.Tag), args.Error(1)
+}
+
+func (m *MockTagRepo) GetAllWithUsage(ctx context.Context) ([]repository.TagWithUsage, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]repository.TagWithUsage), args.Error(1)
+}
+
+func (m *MockTagRepo) SetForTimeEntry(ctx context.Context, timeEntryID int, names []string) error {
+	args := m.Called(ctx, timeEntryID, names)
+	return args.Error(0)
+}
+
+func (m *MockTagRepo) DeleteByTimeEntry(ctx context.Context, timeEntryID int) error {
+	args := m.Called(ctx, timeEntryID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepo) DeleteByProject(ctx context.Context, projectID int) error {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepo) DeleteAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockAbsenceRepo struct {
+	mock.Mock
+}
+
+func (m *MockAbsenceRepo) Create(ctx context.Context, date time.Time, absenceType string, halfDay bool, note *string) (*model.Absence, error) {
+	args := m.Called(ctx, date, absenceType, halfDay, note)
+	return args.Get(0).(*model.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepo) Insert(ctx context.Context, absence model.Absence) (int, error) {
+	args := m.Called(ctx, absence)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAbsenceRepo) GetByID(ctx context.Context, id int) (*model.Absence, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepo) GetInRange(ctx context.Context, from *time.Time, until *time.Time) ([]model.Absence, error) {
+	args := m.Called(ctx, from, until)
+	return args.Get(0).([]model.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepo) DeleteByID(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAbsenceRepo) DeleteAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockTransactor struct {
+	mock.Mock
+	repos repository.Repositories
+}
+
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	m.Called(ctx)
+	return fn(m.repos)
+}
+
 func TestTimeTracking_StartTracking(t *testing.T) {
 	ctx := context.Background()
 	mockProjectRepo := &MockProjectRepo{}
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
 
-	service := &timeTracking{
-		projectRepo:   mockProjectRepo,
-		timeEntryRepo: mockTimeEntryRepo,
-		pauseRepo:     mockPauseRepo,
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
 	}
 
+	service := &timeTracking{transactor: mockTransactor}
+
 	project := &model.Project{ID: 1, Name: "Test Project"}
 	timeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now()}
 
+	mockTransactor.On("WithinTransaction", ctx).Return()
 	mockProjectRepo.On("GetOrCreate", ctx, "Test Project").Return(project, nil)
 	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), nil)
-	mockTimeEntryRepo.On("Create", ctx, 1, mock.AnythingOfType("time.Time"), (*string)(nil)).Return(timeEntry, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]model.TimeEntry{}, nil)
+	mockTimeEntryRepo.On("Create", ctx, 1, mock.AnythingOfType("time.Time"), (*string)(nil), (*string)(nil), true).Return(timeEntry, nil)
 
-	err := service.StartTracking(ctx, "Test Project", false, nil)
+	err := service.StartTracking(ctx, "Test Project", false, EntryAttributes{}, time.Now())
 
 	assert.NoError(t, err)
 	mockProjectRepo.AssertExpectations(t)
@@ -231,20 +426,28 @@ func TestTimeTracking_StartTracking_Force(t *testing.T) {
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
 
-	service := &timeTracking{
-		projectRepo:   mockProjectRepo,
-		timeEntryRepo: mockTimeEntryRepo,
-		pauseRepo:     mockPauseRepo,
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
 	}
 
+	service := &timeTracking{transactor: mockTransactor}
+
 	project := &model.Project{ID: 1, Name: "Test Project"}
-	newEntry := &model.TimeEntry{ID: 2, ProjectID: 1, StartTime: time.Now()}
+	startTime := time.Now()
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime.Add(-time.Hour), Project: project}
+	newEntry := &model.TimeEntry{ID: 2, ProjectID: 1, StartTime: startTime}
 
+	mockTransactor.On("WithinTransaction", ctx).Return()
 	mockProjectRepo.On("GetOrCreate", ctx, "Test Project").Return(project, nil)
-	mockTimeEntryRepo.On("StopAllActive", ctx).Return(nil)
-	mockTimeEntryRepo.On("Create", ctx, 1, mock.AnythingOfType("time.Time"), (*string)(nil)).Return(newEntry, nil)
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockPauseRepo.On("GetActivePause", ctx, 1).Return((*model.Pause)(nil), sql.ErrNoRows)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
+	mockTimeEntryRepo.On("UpdateEndTime", ctx, 1, startTime, time.Hour).Return(nil)
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(activeEntry, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, mock.AnythingOfType("time.Time")).Return([]model.TimeEntry{*activeEntry}, nil)
+	mockTimeEntryRepo.On("Create", ctx, 1, startTime, (*string)(nil), (*string)(nil), true).Return(newEntry, nil)
 
-	err := service.StartTracking(ctx, "Test Project", true, nil)
+	err := service.StartTracking(ctx, "Test Project", true, EntryAttributes{}, startTime)
 
 	assert.NoError(t, err)
 	mockProjectRepo.AssertExpectations(t)
@@ -257,48 +460,110 @@ func TestTimeTracking_StartTracking_AlreadyActive(t *testing.T) {
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
 
-	service := &timeTracking{
-		projectRepo:   mockProjectRepo,
-		timeEntryRepo: mockTimeEntryRepo,
-		pauseRepo:     mockPauseRepo,
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
 	}
 
+	service := &timeTracking{transactor: mockTransactor}
+
 	project := &model.Project{ID: 1, Name: "Existing Project"}
 	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now(), Project: project}
 
+	mockTransactor.On("WithinTransaction", ctx).Return()
 	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
 
-	err := service.StartTracking(ctx, "Test Project", false, nil)
+	err := service.StartTracking(ctx, "Test Project", false, EntryAttributes{}, time.Now())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "already active")
 	mockTimeEntryRepo.AssertExpectations(t)
 }
 
+func TestTimeTracking_StartTracking_Overlapping(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	startTime := time.Now().Add(-time.Hour)
+	existing := model.TimeEntry{ID: 4, ProjectID: 1, StartTime: startTime.Add(-time.Hour), Project: &model.Project{ID: 1, Name: "Other Project"}}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, mock.AnythingOfType("time.Time")).Return([]model.TimeEntry{existing}, nil)
+
+	err := service.StartTracking(ctx, "Test Project", false, EntryAttributes{}, startTime)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overlaps with existing entry 4")
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockProjectRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_StartTracking_ForceOverlapping(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	project := &model.Project{ID: 1, Name: "Test Project"}
+	startTime := time.Now().Add(-2 * time.Hour)
+	activeEntry := &model.TimeEntry{ID: 5, ProjectID: 1, StartTime: startTime.Add(30 * time.Minute), Project: project}
+	previousEnd := startTime.Add(30 * time.Minute)
+	previous := model.TimeEntry{ID: 4, ProjectID: 1, StartTime: startTime.Add(-time.Hour), EndTime: &previousEnd, Project: project}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, mock.AnythingOfType("time.Time")).Return([]model.TimeEntry{previous, *activeEntry}, nil)
+
+	err := service.StartTracking(ctx, "Test Project", true, EntryAttributes{}, startTime)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overlaps with existing entry 4")
+	// The active entry keeps running when the new one is rejected
+	mockTimeEntryRepo.AssertNotCalled(t, "UpdateEndTime", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockProjectRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+}
+
 func TestTimeTracking_StopTracking(t *testing.T) {
 	ctx := context.Background()
 	mockProjectRepo := &MockProjectRepo{}
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
 
-	service := &timeTracking{
-		projectRepo:   mockProjectRepo,
-		timeEntryRepo: mockTimeEntryRepo,
-		pauseRepo:     mockPauseRepo,
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
 	}
 
+	service := &timeTracking{transactor: mockTransactor}
+
 	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now().Add(-time.Hour)}
+	expectedNotes := "Done"
 
+	mockTransactor.On("WithinTransaction", ctx).Return()
 	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockTimeEntryRepo.On("UpdateNotes", ctx, 1, &expectedNotes).Return(nil)
 	mockPauseRepo.On("GetActivePause", ctx, 1).Return((*model.Pause)(nil), sql.ErrNoRows)
 	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
 	mockTimeEntryRepo.On("UpdateEndTime", ctx, 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Duration")).Return(nil)
 	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(activeEntry, nil)
 
-	result, err := service.StopTracking(ctx)
+	result, err := service.StopTracking(ctx, time.Now(), " Done ")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	mockTransactor.AssertExpectations(t)
 	mockTimeEntryRepo.AssertExpectations(t)
 }
 
@@ -308,20 +573,432 @@ func TestTimeTracking_StopTracking_NoActive(t *testing.T) {
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
 
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
+
+	result, err := service.StopTracking(ctx, time.Now(), "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "no active")
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_StopTracking_BeforeStart(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now().Add(-time.Hour)}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+
+	result, err := service.StopTracking(ctx, activeEntry.StartTime.Add(-time.Minute), "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "must not be before the start")
+	mockTimeEntryRepo.AssertNotCalled(t, "UpdateEndTime", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_PauseTracking(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now().Add(-time.Hour)}
+	pauseStart := time.Now().Add(-10 * time.Minute)
+
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockPauseRepo.On("GetActivePause", ctx, 1).Return((*model.Pause)(nil), sql.ErrNoRows)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
+	mockPauseRepo.On("Create", ctx, 1, pauseStart).Return(&model.Pause{ID: 1, TimeEntryID: 1, PauseStart: pauseStart}, nil)
+
+	err := service.PauseTracking(ctx, pauseStart)
+
+	assert.NoError(t, err)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_ContinueTracking_BeforePauseStart(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
 	service := &timeTracking{
 		projectRepo:   mockProjectRepo,
 		timeEntryRepo: mockTimeEntryRepo,
 		pauseRepo:     mockPauseRepo,
 	}
 
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now().Add(-time.Hour)}
+	activePause := &model.Pause{ID: 1, TimeEntryID: 1, PauseStart: time.Now().Add(-10 * time.Minute)}
+
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockPauseRepo.On("GetActivePause", ctx, 1).Return(activePause, nil)
+
+	err := service.ContinueTracking(ctx, activePause.PauseStart.Add(-time.Minute))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must not be before the start of the active pause")
+	mockPauseRepo.AssertNotCalled(t, "EndPause", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_AppendNote(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+	}
+
+	existingNotes := "Reviewed PR"
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: time.Now().Add(-time.Hour), Notes: &existingNotes}
+	expectedNotes := "Reviewed PR\nFixed tests"
+
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil)
+	mockTimeEntryRepo.On("UpdateNotes", ctx, 1, &expectedNotes).Return(nil)
+
+	result, err := service.AppendNote(ctx, "  Fixed tests ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedNotes, *result.Notes)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_AppendNote_Empty(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+	}
+
+	result, err := service.AppendNote(ctx, "   ")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockTimeEntryRepo.AssertNotCalled(t, "GetActive", mock.Anything)
+}
+
+func TestTimeTracking_SwitchTracking(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{
+			Project:   mockProjectRepo,
+			TimeEntry: mockTimeEntryRepo,
+			Pause:     mockPauseRepo,
+		},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	switchTime := time.Now()
+	category := "meeting"
+	oldProject := &model.Project{ID: 1, Name: "Old Project"}
+	newProject := &model.Project{ID: 2, Name: "New Project"}
+	activeEntry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: switchTime.Add(-time.Hour), Project: oldProject}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetActive", ctx).Return(activeEntry, nil).Once()
+	mockPauseRepo.On("GetActivePause", ctx, 1).Return((*model.Pause)(nil), sql.ErrNoRows)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
+	mockTimeEntryRepo.On("UpdateEndTime", ctx, 1, switchTime, time.Hour).Return(nil)
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(activeEntry, nil)
+	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), sql.ErrNoRows).Once()
+	mockTimeEntryRepo.On("GetOverlapping", ctx, switchTime, mock.AnythingOfType("time.Time")).Return([]model.TimeEntry{}, nil)
+	mockProjectRepo.On("GetOrCreate", ctx, "New Project").Return(newProject, nil)
+	mockTimeEntryRepo.On("Create", ctx, 2, switchTime, &category, (*string)(nil), true).Return(&model.TimeEntry{ID: 2, ProjectID: 2, StartTime: switchTime}, nil)
+
+	result, err := service.SwitchTracking(ctx, "New Project", &category, switchTime)
+
+	assert.NoError(t, err)
+	assert.Equal(t, activeEntry, result)
+	mockTransactor.AssertExpectations(t)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_SwitchTracking_NoActive(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{TimeEntry: mockTimeEntryRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
 	mockTimeEntryRepo.On("GetActive", ctx).Return((*model.TimeEntry)(nil), sql.ErrNoRows)
 
-	result, err := service.StopTracking(ctx)
+	result, err := service.SwitchTracking(ctx, "New Project", nil, time.Now())
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "no active")
+	mockTimeEntryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_AddEntry(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 30, 0, 0, time.Local)
+	pauseStart := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	pauseEnd := time.Date(2025, 10, 16, 12, 15, 0, 0, time.Local)
+	category := "development"
+
+	project := &model.Project{ID: 1, Name: "Test Project"}
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime, Project: project}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, endTime).Return([]model.TimeEntry{}, nil)
+	mockProjectRepo.On("GetOrCreate", ctx, "Test Project").Return(project, nil)
+	mockTimeEntryRepo.On("CreateCompleted", ctx, 1, startTime, endTime, 3*time.Hour+15*time.Minute, &category, (*string)(nil), true).Return(entry, nil)
+	mockPauseRepo.On("CreateCompleted", ctx, 1, pauseStart, pauseEnd, 15*time.Minute).Return(&model.Pause{ID: 1, TimeEntryID: 1}, nil)
+	mockTagRepo.On("SetForTimeEntry", ctx, 1, []string{"client-x", "remote"}).Return(nil)
+
+	result, err := service.AddEntry(ctx, "Test Project", startTime, endTime, EntryAttributes{Category: &category, Tags: []string{"client-x", "remote"}}, []model.Pause{
+		{PauseStart: pauseStart, PauseEnd: &pauseEnd},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entry, result)
+	assert.Equal(t, []string{"client-x", "remote"}, result.Tags)
+	mockTransactor.AssertExpectations(t)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_AddEntry_InvalidRange(t *testing.T) {
+	ctx := context.Background()
+	service := &timeTracking{}
+
+	startTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+
+	result, err := service.AddEntry(ctx, "Test Project", startTime, endTime, EntryAttributes{}, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "end time must be after start time")
+}
+
+func TestTimeTracking_AddEntry_PauseOutsideEntry(t *testing.T) {
+	ctx := context.Background()
+	service := &timeTracking{}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	pauseStart := time.Date(2025, 10, 16, 11, 45, 0, 0, time.Local)
+	pauseEnd := time.Date(2025, 10, 16, 12, 15, 0, 0, time.Local)
+
+	result, err := service.AddEntry(ctx, "Test Project", startTime, endTime, EntryAttributes{}, []model.Pause{
+		{PauseStart: pauseStart, PauseEnd: &pauseEnd},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "outside of the time entry")
+}
+
+func TestTimeTracking_AddEntry_Overlapping(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{transactor: mockTransactor}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	existing := model.TimeEntry{ID: 7, ProjectID: 1, StartTime: startTime, Project: &model.Project{ID: 1, Name: "Other Project"}}
+
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, endTime).Return([]model.TimeEntry{existing}, nil)
+
+	result, err := service.AddEntry(ctx, "Test Project", startTime, endTime, EntryAttributes{}, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "overlaps with existing entry 7")
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_EditEntry(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		transactor:    mockTransactor,
+	}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	newStartTime := time.Date(2025, 10, 16, 8, 0, 0, 0, time.Local)
+	pauseDuration := 30 * time.Minute
+	project := &model.Project{ID: 1, Name: "Test Project"}
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime, Project: project}
+	pauses := []model.Pause{{ID: 1, TimeEntryID: 1, PauseStart: startTime.Add(time.Hour), PauseEnd: &endTime, Duration: &pauseDuration}}
+
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(entry, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return(pauses, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, newStartTime, endTime).Return([]model.TimeEntry{*entry}, nil)
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("Update", ctx, mock.MatchedBy(func(e *model.TimeEntry) bool {
+		return e.StartTime.Equal(newStartTime) && e.Duration != nil && *e.Duration == 3*time.Hour+30*time.Minute
+	})).Return(nil)
+
+	result, err := service.EditEntry(ctx, 1, TimeEntryChanges{StartTime: &newStartTime})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
 	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_EditEntry_Tags(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo},
+	}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		tagRepo:       mockTagRepo,
+		transactor:    mockTransactor,
+	}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime}
+
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(entry, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, endTime).Return([]model.TimeEntry{*entry}, nil)
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockTimeEntryRepo.On("Update", ctx, entry).Return(nil)
+	mockTagRepo.On("SetForTimeEntry", ctx, 1, []string{}).Return(nil)
+
+	result, err := service.EditEntry(ctx, 1, TimeEntryChanges{Tags: []string{}})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_EditEntry_EndBeforeStart(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	newEndTime := time.Date(2025, 10, 16, 8, 0, 0, 0, time.Local)
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime}
+
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(entry, nil)
+
+	result, err := service.EditEntry(ctx, 1, TimeEntryChanges{EndTime: &newEndTime})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "end time must not be before start time")
+	mockTimeEntryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTimeTracking_EditEntry_OverlappingKeepsProjects(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+	}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	newEndTime := time.Date(2025, 10, 16, 14, 0, 0, 0, time.Local)
+	projectName := "New Project"
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime}
+	other := model.TimeEntry{ID: 2, ProjectID: 1, StartTime: endTime.Add(time.Hour), Project: &model.Project{ID: 1, Name: "Other Project"}}
+
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(entry, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return([]model.Pause{}, nil)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, startTime, newEndTime).Return([]model.TimeEntry{*entry, other}, nil)
+
+	result, err := service.EditEntry(ctx, 1, TimeEntryChanges{ProjectName: &projectName, EndTime: &newEndTime})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "overlaps with existing entry 2")
+	// The new project is not created for an edit which fails
+	mockProjectRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+	mockTimeEntryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
 func TestTimeTracking_GetActiveEntry(t *testing.T) {
@@ -401,21 +1078,139 @@ func TestTimeTracking_ClearAllData(t *testing.T) {
 	mockProjectRepo := &MockProjectRepo{}
 	mockTimeEntryRepo := &MockTimeEntryRepo{}
 	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
 
 	service := &timeTracking{
 		projectRepo:   mockProjectRepo,
 		timeEntryRepo: mockTimeEntryRepo,
 		pauseRepo:     mockPauseRepo,
+		tagRepo:       mockTagRepo,
+		absenceRepo:   mockAbsenceRepo,
 	}
 
 	mockTimeEntryRepo.On("DeleteAll", ctx).Return(nil)
 	mockPauseRepo.On("DeleteAll", ctx).Return(nil)
+	mockTagRepo.On("DeleteAll", ctx).Return(nil)
+	mockAbsenceRepo.On("DeleteAll", ctx).Return(nil)
 
 	err := service.ClearAllData(ctx)
 
 	assert.NoError(t, err)
 	mockTimeEntryRepo.AssertExpectations(t)
 	mockPauseRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+	mockAbsenceRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_RemoveEntry(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo},
+	}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		tagRepo:       mockTagRepo,
+		transactor:    mockTransactor,
+	}
+
+	entry := &model.TimeEntry{ID: 3, ProjectID: 1, StartTime: time.Now()}
+
+	mockTimeEntryRepo.On("GetByID", ctx, 3).Return(entry, nil)
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockPauseRepo.On("DeleteByTimeEntry", ctx, 3).Return(nil)
+	mockTagRepo.On("DeleteByTimeEntry", ctx, 3).Return(nil)
+	mockTimeEntryRepo.On("DeleteByID", ctx, 3).Return(nil)
+
+	result, err := service.RemoveEntry(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entry, result)
+	mockTransactor.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_RemoveProject(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTagRepo := &MockTagRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo, Tag: mockTagRepo},
+	}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		tagRepo:       mockTagRepo,
+		transactor:    mockTransactor,
+	}
+
+	mockProjectRepo.On("GetByIDOrName", ctx, "client").Return(&model.Project{ID: 2, Name: "client"}, nil)
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockPauseRepo.On("DeleteByProject", ctx, 2).Return(nil)
+	mockTagRepo.On("DeleteByProject", ctx, 2).Return(nil)
+	mockTimeEntryRepo.On("DeleteByProject", ctx, 2).Return(nil)
+	mockProjectRepo.On("DeleteByID", ctx, 2).Return(nil)
+
+	err := service.RemoveProject(ctx, "client")
+
+	assert.NoError(t, err)
+	mockTransactor.AssertExpectations(t)
+	mockProjectRepo.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTimeTracking_RemovePause(t *testing.T) {
+	ctx := context.Background()
+	mockProjectRepo := &MockProjectRepo{}
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockTransactor := &MockTransactor{
+		repos: repository.Repositories{Project: mockProjectRepo, TimeEntry: mockTimeEntryRepo, Pause: mockPauseRepo},
+	}
+
+	service := &timeTracking{
+		projectRepo:   mockProjectRepo,
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		transactor:    mockTransactor,
+	}
+
+	startTime := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	endTime := time.Date(2025, 10, 16, 12, 0, 0, 0, time.Local)
+	remainingPauseDuration := 15 * time.Minute
+	entry := &model.TimeEntry{ID: 1, ProjectID: 1, StartTime: startTime, EndTime: &endTime}
+	pause := &model.Pause{ID: 2, TimeEntryID: 1, PauseStart: startTime.Add(time.Hour)}
+	remainingPauses := []model.Pause{{ID: 3, TimeEntryID: 1, Duration: &remainingPauseDuration}}
+
+	mockPauseRepo.On("GetByID", ctx, 2).Return(pause, nil)
+	mockTransactor.On("WithinTransaction", ctx).Return()
+	mockPauseRepo.On("DeleteByID", ctx, 2).Return(nil)
+	mockTimeEntryRepo.On("GetByID", ctx, 1).Return(entry, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 1).Return(remainingPauses, nil)
+	mockTimeEntryRepo.On("UpdateEndTime", ctx, 1, endTime, 2*time.Hour+45*time.Minute).Return(nil)
+
+	result, err := service.RemovePause(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockTransactor.AssertExpectations(t)
+	mockTimeEntryRepo.AssertExpectations(t)
+	mockPauseRepo.AssertExpectations(t)
 }
 
 func TestTimeTracking_FormatDuration(t *testing.T) {
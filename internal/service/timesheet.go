@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+)
+
+// TimesheetRow holds the work time of a project, or of a category of a project, per day of a timesheet
+type TimesheetRow struct {
+	Project string
+	// Category is only set for timesheets split by category, an entry without category has an empty one
+	Category string
+	Days     []time.Duration
+	Total    time.Duration
+}
+
+// Timesheet holds the work time per project (and category) and day of a week. Absences holds the time credited by
+// absences per day, which is part of the day totals and the total.
+type Timesheet struct {
+	Days      []time.Time
+	Rows      []TimesheetRow
+	Absences  []time.Duration
+	Credited  time.Duration
+	DayTotals []time.Duration
+	Total     time.Duration
+}
+
+// GetTimesheet returns the effective work time per project and day of the week starting at the given time. Entries
+// which span midnight are split across the days at midnight, their pauses are subtracted from the day they fall on.
+// Active entries count until now. With byCategory every category of a project gets its own row. Absences credit
+// their part of the expected work time of the schedule on their day.
+func (s *timeTracking) GetTimesheet(ctx context.Context, schedule WorkSchedule, start time.Time, byCategory bool) (*Timesheet, error) {
+	sheet := &Timesheet{
+		Days:      make([]time.Time, 7),
+		Absences:  make([]time.Duration, 7),
+		DayTotals: make([]time.Duration, 7),
+	}
+	for i := range sheet.Days {
+		sheet.Days[i] = start.AddDate(0, 0, i)
+	}
+	end := start.AddDate(0, 0, 7)
+
+	// Entries which started before the week, e.g. multi-day or long-running active entries, may reach into it
+	entries, err := s.timeEntryRepo.GetOverlapping(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	now := time.Now()
+	rows := make(map[[2]string]*TimesheetRow)
+	for _, entry := range entries {
+		entryEnd := now
+		if entry.EndTime != nil {
+			entryEnd = *entry.EndTime
+		}
+
+		pauses, err := s.pauseRepo.GetByTimeEntry(ctx, entry.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pauses: %w", err)
+		}
+
+		project := fmt.Sprintf("%d", entry.ProjectID)
+		if entry.Project != nil {
+			project = entry.Project.Name
+		}
+
+		var category string
+		if byCategory && entry.Category != nil {
+			category = *entry.Category
+		}
+
+		for i, day := range sheet.Days {
+			worked := workTimeWithin(entry.StartTime, entryEnd, pauses, day, day.AddDate(0, 0, 1))
+			if worked <= 0 {
+				continue
+			}
+
+			key := [2]string{project, category}
+			row, ok := rows[key]
+			if !ok {
+				row = &TimesheetRow{Project: project, Category: category, Days: make([]time.Duration, 7)}
+				rows[key] = row
+			}
+
+			row.Days[i] += worked
+			row.Total += worked
+			sheet.DayTotals[i] += worked
+			sheet.Total += worked
+		}
+	}
+
+	credits, err := s.getAbsenceCredits(ctx, schedule, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, credit := range credits {
+		for i, day := range sheet.Days {
+			if !credit.Date.Equal(startOfDay(day)) {
+				continue
+			}
+
+			sheet.Absences[i] += credit.Credited
+			sheet.Credited += credit.Credited
+			sheet.DayTotals[i] += credit.Credited
+			sheet.Total += credit.Credited
+		}
+	}
+
+	for _, row := range rows {
+		sheet.Rows = append(sheet.Rows, *row)
+	}
+
+	sort.Slice(sheet.Rows, func(i, j int) bool {
+		if sheet.Rows[i].Project != sheet.Rows[j].Project {
+			return sheet.Rows[i].Project < sheet.Rows[j].Project
+		}
+		return sheet.Rows[i].Category < sheet.Rows[j].Category
+	})
+
+	return sheet, nil
+}
+
+// workTimeWithin returns the part of the work time between start and end which lies within the given period. Pauses
+// without an end last until the end of the entry.
+func workTimeWithin(start time.Time, end time.Time, pauses []model.Pause, periodStart time.Time, periodEnd time.Time) time.Duration {
+	worked := overlap(start, end, periodStart, periodEnd)
+	if worked <= 0 {
+		return 0
+	}
+
+	for _, pause := range pauses {
+		pauseEnd := end
+		if pause.PauseEnd != nil {
+			pauseEnd = *pause.PauseEnd
+		}
+		worked -= overlap(pause.PauseStart, pauseEnd, periodStart, periodEnd)
+	}
+
+	return max(worked, 0)
+}
+
+// overlap returns the length of the intersection of two time ranges
+func overlap(aStart time.Time, aEnd time.Time, bStart time.Time, bEnd time.Time) time.Duration {
+	start := aStart
+	if bStart.After(start) {
+		start = bStart
+	}
+
+	end := aEnd
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+
+	return end.Sub(start)
+}
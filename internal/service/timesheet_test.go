@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTracking_GetTimesheet(t *testing.T) {
+	ctx := context.Background()
+	mockTimeEntryRepo := &MockTimeEntryRepo{}
+	mockPauseRepo := &MockPauseRepo{}
+	mockAbsenceRepo := &MockAbsenceRepo{}
+
+	service := &timeTracking{
+		timeEntryRepo: mockTimeEntryRepo,
+		pauseRepo:     mockPauseRepo,
+		absenceRepo:   mockAbsenceRepo,
+	}
+
+	// Monday, 2025-10-20
+	start := time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 7)
+	sunday := start.AddDate(0, 0, 6)
+	at := func(day int, hour int) time.Time {
+		return start.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+	}
+
+	client := &model.Project{ID: 1, Name: "Client"}
+	internal := &model.Project{ID: 2, Name: "Internal"}
+	meeting := "meeting"
+
+	newEntry := func(id int, project *model.Project, category *string, start time.Time, end time.Time) model.TimeEntry {
+		duration := end.Sub(start)
+		return model.TimeEntry{ID: id, ProjectID: project.ID, Project: project, Category: category, StartTime: start, EndTime: &end, Duration: &duration}
+	}
+
+	entries := []model.TimeEntry{
+		// Saturday before the week until Monday night, 1 hour reaches into Monday
+		newEntry(1, client, nil, at(-2, 22), at(0, 1)),
+		newEntry(2, client, &meeting, at(0, 9), at(0, 10)),
+		// Tuesday night until Wednesday with a pause after midnight
+		newEntry(3, internal, nil, at(1, 22), at(2, 3)),
+		newEntry(4, client, nil, at(6, 10), at(6, 12)),
+	}
+
+	pauseStart := at(2, 1)
+	pauseEnd := at(2, 2)
+	mockTimeEntryRepo.On("GetOverlapping", ctx, start, end).Return(entries, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, 3).Return([]model.Pause{{PauseStart: pauseStart, PauseEnd: &pauseEnd}}, nil)
+	mockPauseRepo.On("GetByTimeEntry", ctx, mock.Anything).Return([]model.Pause{}, nil)
+	mockAbsenceRepo.On("GetInRange", ctx, &start, &sunday).Return([]model.Absence{
+		{ID: 1, Date: start.AddDate(0, 0, 4), Type: model.AbsenceTypeHoliday, HalfDay: true},
+	}, nil)
+
+	sheet, err := service.GetTimesheet(ctx, weekdaySchedule(8*time.Hour), start, false)
+
+	require.NoError(t, err)
+	require.Len(t, sheet.Days, 7)
+	assert.Equal(t, start, sheet.Days[0])
+
+	require.Len(t, sheet.Rows, 2)
+	assert.Equal(t, "Client", sheet.Rows[0].Project)
+	assert.Equal(t, []time.Duration{2 * time.Hour, 0, 0, 0, 0, 0, 2 * time.Hour}, sheet.Rows[0].Days)
+	assert.Equal(t, 4*time.Hour, sheet.Rows[0].Total)
+
+	assert.Equal(t, "Internal", sheet.Rows[1].Project)
+	assert.Equal(t, []time.Duration{0, 2 * time.Hour, 2 * time.Hour, 0, 0, 0, 0}, sheet.Rows[1].Days)
+
+	// The half day holiday on Friday credits half of the expected work time
+	assert.Equal(t, []time.Duration{0, 0, 0, 0, 4 * time.Hour, 0, 0}, sheet.Absences)
+	assert.Equal(t, 4*time.Hour, sheet.Credited)
+	assert.Equal(t, []time.Duration{2 * time.Hour, 2 * time.Hour, 2 * time.Hour, 0, 4 * time.Hour, 0, 2 * time.Hour}, sheet.DayTotals)
+	assert.Equal(t, 12*time.Hour, sheet.Total)
+
+	sheet, err = service.GetTimesheet(ctx, weekdaySchedule(8*time.Hour), start, true)
+
+	require.NoError(t, err)
+	require.Len(t, sheet.Rows, 3)
+	assert.Equal(t, "", sheet.Rows[0].Category)
+	assert.Equal(t, 3*time.Hour, sheet.Rows[0].Total)
+	assert.Equal(t, "meeting", sheet.Rows[1].Category)
+	assert.Equal(t, time.Hour, sheet.Rows[1].Total)
+}
@@ -0,0 +1,396 @@
+package ui
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nitschmann/hora/internal/repository"
+)
+
+//go:embed templates/report.html
+var reportTemplate string
+
+// chartColors are the colors of the project and category charts, the same as in the dashboard
+var chartColors = []string{
+	"#667eea", "#764ba2", "#f093fb", "#f5576c",
+	"#4facfe", "#00f2fe", "#43e97b", "#38f9d7",
+	"#ffecd2", "#fcb69f", "#a8edea", "#fed6e3",
+}
+
+// noCategory is the label of entries without a category in the charts
+const noCategory = "No Category"
+
+// Report is the data of an HTML report. Since and Until are the optional inclusive lower and exclusive upper bound of
+// the reported period, without them the daily activity covers the days from the first to the last entry.
+type Report struct {
+	Entries     []repository.TimeEntryWithPauses
+	Since       *time.Time
+	Until       *time.Time
+	Filters     []string
+	GeneratedAt time.Time
+}
+
+// reportSlice is a slice of a doughnut chart and its legend entry
+type reportSlice struct {
+	Label string
+	Hours float64
+	Color string
+	Path  string
+}
+
+// reportBar is a bar of the daily activity chart
+type reportBar struct {
+	Label  string
+	Hours  float64
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+	// Center is the horizontal center of the bar where its label is anchored
+	Center float64
+	// ShowLabel is false for bars whose label is left out so the labels of long periods do not overlap
+	ShowLabel bool
+}
+
+// reportDoughnut is a doughnut chart whose slices filter the entry table by project or category
+type reportDoughnut struct {
+	Title  string
+	Filter string
+	Slices []reportSlice
+}
+
+// reportTick is a tick of the y axis of the daily activity chart
+type reportTick struct {
+	Label string
+	Y     float64
+}
+
+// reportEntry is a row of the entry table
+type reportEntry struct {
+	Date     string
+	Time     string
+	Project  string
+	Category string
+	// CategoryLabel is the category as labeled in the chart, used to filter the table by category
+	CategoryLabel string
+	Tags          string
+	Notes         string
+	Pause         string
+	Hours         string
+}
+
+// reportData is the data of the report template
+type reportData struct {
+	Period        string
+	Filters       []string
+	GeneratedAt   string
+	TotalHours    string
+	Projects      int
+	Categories    int
+	EntryCount    int
+	AvgSession    string
+	Days          []reportBar
+	Ticks         []reportTick
+	ProjectChart  reportDoughnut
+	CategoryChart reportDoughnut
+	Entries       []reportEntry
+}
+
+// Dimensions of the daily activity chart in SVG user units
+const (
+	barChartWidth  = 1000.0
+	barChartHeight = 400.0
+	barChartLeft   = 50.0
+	barChartBottom = 80.0
+	barChartTop    = 10.0
+)
+
+// WriteReport writes a self-contained HTML report of the given time entries with the charts of the dashboard and a
+// table of all entries. The charts are inline SVG and the page has no external resources, so it can be viewed offline.
+// Like in the dashboard the charts show the effective work time, running entries only appear in the table.
+func WriteReport(w io.Writer, report Report) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"hours": func(h float64) string { return fmt.Sprintf("%.1fh", h) },
+	}).Parse(reportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	data := reportData{
+		Period:      reportPeriod(report),
+		Filters:     report.Filters,
+		GeneratedAt: report.GeneratedAt.Format("02/01/2006 15:04"),
+		EntryCount:  len(report.Entries),
+	}
+
+	projectHours := make(map[string]float64)
+	categoryHours := make(map[string]float64)
+	dayHours := make(map[string]float64)
+	var totalHours float64
+
+	for _, entry := range report.Entries {
+		hours := entryHours(entry)
+		totalHours += hours
+
+		projectHours[entryProject(entry)] += hours
+		categoryHours[entryCategory(entry)] += hours
+		dayHours[entry.StartTime.Local().Format(time.DateOnly)] += hours
+
+		data.Entries = append(data.Entries, newReportEntry(entry))
+	}
+
+	data.TotalHours = fmt.Sprintf("%.1f", totalHours)
+	data.Projects = len(projectHours)
+	data.Categories = len(categoryHours)
+	if len(report.Entries) > 0 {
+		data.AvgSession = fmt.Sprintf("%.1f", totalHours/float64(len(report.Entries)))
+	} else {
+		data.AvgSession = "0.0"
+	}
+
+	data.ProjectChart = reportDoughnut{Title: "Time by Project", Filter: "project", Slices: doughnutSlices(projectHours)}
+	data.CategoryChart = reportDoughnut{Title: "Time by Category", Filter: "category", Slices: doughnutSlices(categoryHours)}
+	data.Days, data.Ticks = dailyBars(reportDays(report), dayHours)
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return nil
+}
+
+// entryHours returns the effective work time of an entry in hours, zero for running entries
+func entryHours(entry repository.TimeEntryWithPauses) float64 {
+	if entry.Duration == nil {
+		return 0
+	}
+
+	return entry.Duration.Hours()
+}
+
+// entryProject returns the project name of an entry
+func entryProject(entry repository.TimeEntryWithPauses) string {
+	if entry.Project == nil {
+		return fmt.Sprintf("Project %d", entry.ProjectID)
+	}
+
+	return entry.Project.Name
+}
+
+// entryCategory returns the category of an entry or the label of entries without one
+func entryCategory(entry repository.TimeEntryWithPauses) string {
+	if entry.Category == nil || *entry.Category == "" {
+		return noCategory
+	}
+
+	return *entry.Category
+}
+
+// newReportEntry creates the table row of an entry
+func newReportEntry(entry repository.TimeEntryWithPauses) reportEntry {
+	start := entry.StartTime.Local()
+	row := reportEntry{
+		Date:          start.Format("02/01/2006"),
+		Time:          start.Format("15:04") + " - ongoing",
+		Project:       entryProject(entry),
+		CategoryLabel: entryCategory(entry),
+		Tags:          strings.Join(entry.Tags, ", "),
+		Hours:         fmt.Sprintf("%.1fh", entryHours(entry)),
+	}
+
+	if entry.EndTime != nil {
+		row.Time = start.Format("15:04") + " - " + entry.EndTime.Local().Format("15:04")
+	}
+	if entry.Category != nil {
+		row.Category = *entry.Category
+	}
+	if entry.Notes != nil {
+		row.Notes = *entry.Notes
+	}
+	if entry.PauseTime > 0 {
+		row.Pause = fmt.Sprintf("%.1fh", entry.PauseTime.Hours())
+	}
+
+	return row
+}
+
+// reportPeriod describes the reported period
+func reportPeriod(report Report) string {
+	switch {
+	case report.Since != nil && report.Until != nil:
+		return fmt.Sprintf("%s – %s", report.Since.Format(time.DateOnly), report.Until.Add(-time.Nanosecond).Format(time.DateOnly))
+	case report.Since != nil:
+		return "Since " + report.Since.Format(time.DateOnly)
+	case report.Until != nil:
+		return "Until " + report.Until.Add(-time.Nanosecond).Format(time.DateOnly)
+	default:
+		return "All time"
+	}
+}
+
+// reportDays returns the days of the daily activity chart. They range from the start of the period or the first entry
+// to the end of the period or the last entry.
+func reportDays(report Report) []time.Time {
+	var first, last time.Time
+	for _, entry := range report.Entries {
+		start := entry.StartTime.Local()
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if last.IsZero() || start.After(last) {
+			last = start
+		}
+	}
+
+	if report.Since != nil {
+		first = *report.Since
+	}
+	if report.Until != nil {
+		last = report.Until.Add(-time.Nanosecond)
+	}
+	if first.IsZero() || last.IsZero() {
+		return nil
+	}
+
+	var days []time.Time
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, first.Location())
+	for !day.After(last) {
+		days = append(days, day)
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return days
+}
+
+// dailyBars lays out the bars and y axis ticks of the daily activity chart
+func dailyBars(days []time.Time, dayHours map[string]float64) ([]reportBar, []reportTick) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+
+	var maxHours float64
+	for _, day := range days {
+		maxHours = math.Max(maxHours, dayHours[day.Format(time.DateOnly)])
+	}
+
+	step := tickStep(maxHours)
+	top := math.Max(step, math.Ceil(maxHours/step)*step)
+	plotHeight := barChartHeight - barChartTop - barChartBottom
+	plotWidth := barChartWidth - barChartLeft
+
+	var ticks []reportTick
+	for value := 0.0; value <= top+step/2; value += step {
+		ticks = append(ticks, reportTick{
+			Label: fmt.Sprintf("%gh", math.Round(value*100)/100),
+			Y:     round2(barChartTop + plotHeight - value/top*plotHeight),
+		})
+	}
+
+	slot := plotWidth / float64(len(days))
+	labelEvery := int(math.Ceil(float64(len(days)) / 15))
+	bars := make([]reportBar, 0, len(days))
+	for i, day := range days {
+		hours := dayHours[day.Format(time.DateOnly)]
+		height := hours / top * plotHeight
+		x := barChartLeft + float64(i)*slot
+		bars = append(bars, reportBar{
+			Label:     day.Format("Mon, Jan 2"),
+			Hours:     hours,
+			X:         round2(x + slot*0.1),
+			Y:         round2(barChartTop + plotHeight - height),
+			Width:     round2(slot * 0.8),
+			Height:    round2(height),
+			Center:    round2(x + slot/2),
+			ShowLabel: i%labelEvery == 0,
+		})
+	}
+
+	return bars, ticks
+}
+
+// round2 rounds a coordinate to two decimals to keep the SVG small
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// tickStep returns a step of 1, 2 or 5 times a power of ten for about five ticks up to the given maximum
+func tickStep(maxValue float64) float64 {
+	if maxValue <= 0 {
+		return 1
+	}
+
+	raw := maxValue / 5
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 5} {
+		if raw <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
+
+// doughnutSlices returns the slices of a doughnut chart of the given hours by label, the largest first. Labels without
+// tracked time are left out of the chart but kept in the legend.
+func doughnutSlices(hoursByLabel map[string]float64) []reportSlice {
+	parts := make([]reportSlice, 0, len(hoursByLabel))
+	var total float64
+	for label, hours := range hoursByLabel {
+		parts = append(parts, reportSlice{Label: label, Hours: hours})
+		total += hours
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		if parts[i].Hours != parts[j].Hours {
+			return parts[i].Hours > parts[j].Hours
+		}
+		return parts[i].Label < parts[j].Label
+	})
+
+	var angle float64
+	for i := range parts {
+		parts[i].Color = chartColors[i%len(chartColors)]
+		if total <= 0 || parts[i].Hours <= 0 {
+			continue
+		}
+
+		sweep := parts[i].Hours / total * 2 * math.Pi
+		parts[i].Path = doughnutPath(angle, angle+sweep)
+		angle += sweep
+	}
+
+	return parts
+}
+
+// Radii of the doughnut charts in SVG user units, the charts are centered at the origin
+const (
+	doughnutOuter = 100.0
+	doughnutInner = 50.0
+)
+
+// doughnutPath returns the SVG path of a doughnut segment between two angles in radians, clockwise from the top
+func doughnutPath(from float64, to float64) string {
+	// A full circle cannot be drawn with a single arc, so it is split into two halves
+	if to-from >= 2*math.Pi-1e-9 {
+		return doughnutPath(from, from+math.Pi) + " " + doughnutPath(from+math.Pi, to)
+	}
+
+	point := func(radius float64, angle float64) string {
+		return fmt.Sprintf("%.3f %.3f", radius*math.Sin(angle), -radius*math.Cos(angle))
+	}
+
+	large := 0
+	if to-from > math.Pi {
+		large = 1
+	}
+
+	return fmt.Sprintf("M %s A %g %g 0 %d 1 %s L %s A %g %g 0 %d 0 %s Z",
+		point(doughnutOuter, from), doughnutOuter, doughnutOuter, large, point(doughnutOuter, to),
+		point(doughnutInner, to), doughnutInner, doughnutInner, large, point(doughnutInner, from))
+}
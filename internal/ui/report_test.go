@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReport(t *testing.T) {
+	start := time.Date(2025, 10, 16, 9, 0, 0, 0, time.Local)
+	end := start.Add(3 * time.Hour)
+	work := 150 * time.Minute
+	dev := "dev"
+	notes := "<script>alert(1)</script>"
+	client := &model.Project{ID: 1, Name: "Client"}
+
+	entries := []repository.TimeEntryWithPauses{
+		{
+			TimeEntry:  model.TimeEntry{ID: 1, ProjectID: 1, Project: client, StartTime: start, EndTime: &end, Duration: &work, Category: &dev, Notes: &notes, Tags: []string{"remote"}},
+			PauseCount: 1,
+			PauseTime:  30 * time.Minute,
+		},
+		{TimeEntry: model.TimeEntry{ID: 2, ProjectID: 2, Project: &model.Project{ID: 2, Name: "Website"}, StartTime: start.AddDate(0, 0, 2)}},
+	}
+
+	since := time.Date(2025, 10, 13, 0, 0, 0, 0, time.Local)
+	until := since.AddDate(0, 0, 7)
+
+	var buf bytes.Buffer
+	err := WriteReport(&buf, Report{Entries: entries, Since: &since, Until: &until, Filters: []string{"Tags: remote"}, GeneratedAt: end})
+
+	require.NoError(t, err)
+	html := buf.String()
+	assert.Contains(t, html, "2025-10-13 – 2025-10-19")
+	assert.Contains(t, html, "Tags: remote")
+	assert.Contains(t, html, "Time by Project")
+	assert.Contains(t, html, "Time by Category")
+	assert.Contains(t, html, "Thu, Oct 16: 2.5h")
+	assert.Contains(t, html, "09:00 - 12:00")
+	assert.Contains(t, html, "09:00 - ongoing")
+	assert.Contains(t, html, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, html, "ZgotmplZ")
+	// Offline: nothing is loaded from elsewhere
+	assert.NotContains(t, html, "http")
+	// A label per day of the period and a bar per day with tracked time
+	assert.Equal(t, 7, bytes.Count(buf.Bytes(), []byte(`transform="rotate(-45`)))
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`<rect class="bar"`)))
+}
+
+func TestDoughnutSlices(t *testing.T) {
+	slices := doughnutSlices(map[string]float64{"Website": 1, "Client": 3, "Idle": 0})
+
+	require.Len(t, slices, 3)
+	assert.Equal(t, "Client", slices[0].Label)
+	assert.Equal(t, chartColors[0], slices[0].Color)
+	assert.Equal(t, "Website", slices[1].Label)
+	assert.NotEmpty(t, slices[1].Path)
+	// Labels without time are only shown in the legend
+	assert.Equal(t, "Idle", slices[2].Label)
+	assert.Empty(t, slices[2].Path)
+
+	// A single slice is a full ring made of two halves
+	full := doughnutSlices(map[string]float64{"Client": 2})
+	assert.Equal(t, 2, bytes.Count([]byte(full[0].Path), []byte("M ")))
+}
+
+func TestTickStep(t *testing.T) {
+	assert.Equal(t, 1.0, tickStep(0))
+	assert.Equal(t, 1.0, tickStep(4.5))
+	assert.Equal(t, 2.0, tickStep(8))
+	assert.Equal(t, 5.0, tickStep(24))
+	assert.InDelta(t, 0.2, tickStep(0.75), 1e-9)
+}
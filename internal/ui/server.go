@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/nitschmann/hora/internal/model"
+	"github.com/nitschmann/hora/internal/repository"
 	"github.com/nitschmann/hora/internal/service"
 )
 
@@ -17,10 +20,18 @@ var staticFS embed.FS
 
 type Server struct {
 	timeService service.TimeTracking
+	rounding    model.Rounding
 }
 
-func NewServer(ts service.TimeTracking) *Server {
-	return &Server{timeService: ts}
+// entryResponse is a time entry of the entries API together with its rounded work time
+type entryResponse struct {
+	repository.TimeEntryWithPauses
+	RoundedDuration *time.Duration `json:"rounded_duration,omitempty"`
+}
+
+// NewServer creates a web UI server, the given rounding applies to all projects without a rounding override
+func NewServer(ts service.TimeTracking, rounding model.Rounding) *Server {
+	return &Server{timeService: ts, rounding: rounding}
 }
 
 func (s *Server) Start(ctx context.Context, port int) error {
@@ -47,14 +58,48 @@ func (s *Server) Start(ctx context.Context, port int) error {
 			}
 		}
 
-		entries, err := s.timeService.GetAllEntriesWithPauses(ctx, limit, "desc", since)
+		// Tags can be given multiple times or comma separated, entries need any of them unless tags_match=all
+		var tags []string
+		for _, value := range query["tag"] {
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+		}
+
+		entries, err := s.timeService.GetAllEntriesWithPausesFiltered(ctx, limit, "desc", repository.TimeEntryFilter{
+			Since:        since,
+			Tags:         tags,
+			MatchAllTags: query.Get("tags_match") == "all",
+		})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
+		// The rounding rules apply unless the exact work time is requested with raw=true
+		rounding := service.NewRoundingRules(model.Rounding{Mode: model.RoundingModeNone})
+		if query.Get("raw") != "true" {
+			rounding, err = s.timeService.GetRoundingRules(ctx, s.rounding)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		rounded := rounding.RoundEntries(entries)
+		response := make([]entryResponse, 0, len(entries))
+		for _, entry := range entries {
+			item := entryResponse{TimeEntryWithPauses: entry}
+			if duration, ok := rounded[entry.ID]; ok {
+				item.RoundedDuration = &duration
+			}
+			response = append(response, item)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(entries)
+		json.NewEncoder(w).Encode(response)
 	})
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
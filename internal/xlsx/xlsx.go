@@ -0,0 +1,373 @@
+// Package xlsx writes simple Office Open XML workbooks (.xlsx) which can be opened by Excel, LibreOffice and Numbers.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSheetNameLength is the maximum length of a sheet name
+const MaxSheetNameLength = 31
+
+// invalidSheetNameChars are the characters which are not allowed in sheet names
+const invalidSheetNameChars = `[]:*?/\`
+
+// Sheet is a worksheet with a bold header row which stays visible when scrolling
+type Sheet struct {
+	Name   string
+	Header []string
+	Rows   [][]Cell
+}
+
+// Cell is a cell of a worksheet. The type of the value determines how it is stored and formatted: strings as text,
+// ints as numbers, float64 as numbers with two decimals, time.Time as date and time in its own timezone and
+// time.Duration as time value like [h]:mm:ss. A nil value is an empty cell.
+type Cell struct {
+	Value any
+	// Formula is computed by the spreadsheet application, Value is its result until the workbook is recalculated
+	Formula string
+}
+
+// Cell styles, their index refers to the cellXfs of the styles part
+const (
+	styleDefault = iota
+	styleDateTime
+	styleDuration
+	styleDecimal
+	styleHeader
+)
+
+// excelEpoch is the day zero of the 1900 date system of Excel, which includes its leap day bug in 1900
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Write writes a workbook with the given sheets. The sheet names have to be unique and valid, see SheetName.
+func Write(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("workbook needs at least one sheet")
+	}
+
+	names := make(map[string]bool, len(sheets))
+	for _, sheet := range sheets {
+		if sheet.Name == "" || utf8.RuneCountInString(sheet.Name) > MaxSheetNameLength || strings.ContainsAny(sheet.Name, invalidSheetNameChars) {
+			return fmt.Errorf("invalid sheet name %q", sheet.Name)
+		}
+
+		lower := strings.ToLower(sheet.Name)
+		if names[lower] {
+			return fmt.Errorf("duplicate sheet name %q", sheet.Name)
+		}
+		names[lower] = true
+	}
+
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", []byte(rootRels)},
+		{"xl/workbook.xml", workbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(sheets))},
+		{"xl/styles.xml", []byte(styles)},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content []byte
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(sheet, i == 0)})
+	}
+
+	archive := zip.NewWriter(w)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := file.Write(part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// SheetName turns a name into a valid sheet name which is not in taken yet. Invalid characters are replaced, long
+// names are shortened and a number is appended to duplicates.
+func SheetName(name string, taken []string) string {
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidSheetNameChars, r) {
+			return '_'
+		}
+		return r
+	}, name))
+	// Names cannot start or end with an apostrophe, as it quotes the name in formulas
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = "Sheet"
+	}
+
+	isTaken := func(candidate string) bool {
+		for _, other := range taken {
+			if strings.EqualFold(other, candidate) {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := truncate(name, MaxSheetNameLength)
+	for i := 2; isTaken(candidate); i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncate(name, MaxSheetNameLength-len(suffix)) + suffix
+	}
+
+	return candidate
+}
+
+// truncate shortens a sheet name to at most the given number of runes, without trailing spaces and apostrophes
+func truncate(name string, length int) string {
+	runes := []rune(name)
+	if len(runes) <= length {
+		return name
+	}
+
+	return strings.TrimRight(string(runes[:length]), " '")
+}
+
+// ColumnName returns the letters of the column with the given zero-based index, e.g. A for 0 and AA for 26
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+// CellRef returns the reference of the cell with the given zero-based column and row index, e.g. B3 for 1 and 2
+func CellRef(column int, row int) string {
+	return ColumnName(column) + strconv.Itoa(row+1)
+}
+
+// SheetRef returns the quoted name of a sheet for use in references of formulas, e.g. 'Client'!A1
+func SheetRef(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// SerialTime returns the serial number of Excel for the wall clock time of t
+func SerialTime(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Seconds() / 86400
+}
+
+// SerialDuration returns a duration as fraction of days, which is how Excel stores durations
+func SerialDuration(d time.Duration) float64 {
+	return d.Seconds() / 86400
+}
+
+// worksheet creates the XML of a sheet, the selected sheet is active when the workbook is opened
+func worksheet(sheet Sheet, selected bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	tabSelected := ""
+	if selected {
+		tabSelected = ` tabSelected="1"`
+	}
+	fmt.Fprintf(&buf, `<sheetViews><sheetView workbookViewId="0"%s>`, tabSelected)
+	if len(sheet.Header) > 0 {
+		buf.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	}
+	buf.WriteString(`</sheetView></sheetViews>`)
+
+	if widths := columnWidths(sheet); len(widths) > 0 {
+		buf.WriteString(`<cols>`)
+		for i, width := range widths {
+			fmt.Fprintf(&buf, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		buf.WriteString(`</cols>`)
+	}
+
+	buf.WriteString(`<sheetData>`)
+	row := 0
+	if len(sheet.Header) > 0 {
+		fmt.Fprintf(&buf, `<row r="%d">`, row+1)
+		for column, title := range sheet.Header {
+			writeCell(&buf, CellRef(column, row), Cell{Value: title}, styleHeader)
+		}
+		buf.WriteString(`</row>`)
+		row++
+	}
+	for _, cells := range sheet.Rows {
+		fmt.Fprintf(&buf, `<row r="%d">`, row+1)
+		for column, cell := range cells {
+			writeCell(&buf, CellRef(column, row), cell, -1)
+		}
+		buf.WriteString(`</row>`)
+		row++
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+
+	return buf.Bytes()
+}
+
+// writeCell writes the XML of a cell, a negative style is derived from the value
+func writeCell(buf *bytes.Buffer, ref string, cell Cell, style int) {
+	if cell.Value == nil && cell.Formula == "" {
+		return
+	}
+
+	var value string
+	isString := false
+	derived := styleDefault
+	switch v := cell.Value.(type) {
+	case string:
+		value, isString = v, true
+	case int:
+		value = strconv.Itoa(v)
+	case float64:
+		value, derived = strconv.FormatFloat(v, 'f', -1, 64), styleDecimal
+	case time.Time:
+		value, derived = strconv.FormatFloat(SerialTime(v), 'f', -1, 64), styleDateTime
+	case time.Duration:
+		value, derived = strconv.FormatFloat(SerialDuration(v), 'f', -1, 64), styleDuration
+	case nil:
+	default:
+		value, isString = fmt.Sprint(v), true
+	}
+	if style < 0 {
+		style = derived
+	}
+
+	styleAttr := ""
+	if style != styleDefault {
+		styleAttr = fmt.Sprintf(` s="%d"`, style)
+	}
+
+	switch {
+	case cell.Formula != "":
+		typeAttr := ""
+		if isString {
+			typeAttr = ` t="str"`
+		}
+		fmt.Fprintf(buf, `<c r="%s"%s%s><f>%s</f>`, ref, styleAttr, typeAttr, escape(cell.Formula))
+		if cell.Value != nil {
+			fmt.Fprintf(buf, `<v>%s</v>`, escape(value))
+		}
+		buf.WriteString(`</c>`)
+	case isString:
+		fmt.Fprintf(buf, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, escape(value))
+	default:
+		fmt.Fprintf(buf, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, value)
+	}
+}
+
+// columnWidths returns the widths of the columns of a sheet in characters, based on their longest text
+func columnWidths(sheet Sheet) []int {
+	var widths []int
+	grow := func(column int, width int) {
+		for len(widths) <= column {
+			widths = append(widths, 8)
+		}
+		widths[column] = min(max(widths[column], width+2), 60)
+	}
+
+	for column, title := range sheet.Header {
+		grow(column, utf8.RuneCountInString(title))
+	}
+	for _, cells := range sheet.Rows {
+		for column, cell := range cells {
+			switch v := cell.Value.(type) {
+			case string:
+				grow(column, utf8.RuneCountInString(v))
+			case time.Time:
+				grow(column, len("2006-01-02 15:04:05"))
+			default:
+				grow(column, 8)
+			}
+		}
+	}
+
+	return widths
+}
+
+// escape escapes text for XML, characters which are not allowed in XML are replaced
+func escape(value string) string {
+	var buf strings.Builder
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+// contentTypes creates the content types part
+func contentTypes(sheets int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	buf.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	buf.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	buf.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	buf.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&buf, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	buf.WriteString(`</Types>`)
+
+	return buf.Bytes()
+}
+
+// workbook creates the workbook part, which lists the sheets and recalculates the formulas when it is opened
+func workbook(sheets []Sheet) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&buf, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	buf.WriteString(`</sheets><calcPr calcId="0" fullCalcOnLoad="1"/></workbook>`)
+
+	return buf.Bytes()
+}
+
+// workbookRels creates the relationships of the workbook to its sheets and styles
+func workbookRels(sheets int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	buf.WriteString(`</Relationships>`)
+
+	return buf.Bytes()
+}
+
+// rootRels is the relationship of the package to the workbook
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles defines the number formats and fonts of the cell styles in the order of their constants
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/><numFmt numFmtId="165" formatCode="[h]:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,98 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2025, 10, 16, 9, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	var buf bytes.Buffer
+	err := Write(&buf, []Sheet{
+		{
+			Name:   "Summary",
+			Header: []string{"Project", "Work Time"},
+			Rows:   [][]Cell{{{Value: "Client"}, {Value: 90 * time.Minute, Formula: "SUM('Client''s'!B2:B2)"}}},
+		},
+		{
+			Name:   "Client's",
+			Header: []string{"Start", "Work Time", "Amount", "Notes"},
+			Rows:   [][]Cell{{{Value: start}, {Value: 90 * time.Minute}, {Value: 120.5}, {Value: "<b>Login</b> & \x01more"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		// Every part has to be well-formed XML
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, file.Name)
+		}
+
+		parts[file.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "_rels/.rels")
+	assert.Contains(t, parts, "xl/_rels/workbook.xml.rels")
+	assert.Contains(t, parts, "xl/styles.xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Client&#39;s" sheetId="2" r:id="rId2"/>`)
+
+	summary := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, summary, `tabSelected="1"`)
+	assert.Contains(t, summary, `<c r="A1" s="4" t="inlineStr"><is><t xml:space="preserve">Project</t></is></c>`)
+	assert.Contains(t, summary, `<c r="B2" s="2"><f>SUM(&#39;Client&#39;&#39;s&#39;!B2:B2)</f><v>0.0625</v></c>`)
+
+	sheet := parts["xl/worksheets/sheet2.xml"]
+	// The wall clock time is kept, 2025-10-16 is day 45946 of Excel
+	assert.Contains(t, sheet, `<c r="A2" s="1"><v>45946.375</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2" s="3"><v>120.5</v></c>`)
+	assert.Contains(t, sheet, `&lt;b&gt;Login&lt;/b&gt; &amp; `)
+}
+
+func TestWrite_InvalidSheets(t *testing.T) {
+	assert.Error(t, Write(io.Discard, nil))
+	assert.Error(t, Write(io.Discard, []Sheet{{Name: "a/b"}}))
+	assert.Error(t, Write(io.Discard, []Sheet{{Name: "Client"}, {Name: "client"}}))
+}
+
+func TestSheetName(t *testing.T) {
+	assert.Equal(t, "Client", SheetName("Client", nil))
+	assert.Equal(t, "Client (2)", SheetName("Client", []string{"client"}))
+	assert.Equal(t, "Client (3)", SheetName("Client", []string{"Client", "Client (2)"}))
+	assert.Equal(t, "a_b_c", SheetName("a/b:c", nil))
+	assert.Equal(t, "Sheet", SheetName("'", nil))
+	assert.Equal(t, "A very long project name which", SheetName("A very long project name which is cut", nil))
+	assert.Equal(t, "A very long project name wh (2)", SheetName("A very long project name which is cut", []string{"A very long project name which"}))
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", CellRef(0, 0))
+	assert.Equal(t, "Z10", CellRef(25, 9))
+	assert.Equal(t, "AA2", CellRef(26, 1))
+	assert.Equal(t, "AZ1", CellRef(51, 0))
+	assert.Equal(t, "BA1", CellRef(52, 0))
+	assert.Equal(t, "'Client''s'", SheetRef("Client's"))
+}